@@ -5,6 +5,7 @@ import (
 	"context"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/hairizuanbinnoorazman/techmeetup/streaming"
@@ -13,9 +14,16 @@ import (
 	"github.com/hairizuanbinnoorazman/techmeetup/eventmgmt"
 	"github.com/hairizuanbinnoorazman/techmeetup/eventstore"
 	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+	sheetsZ "github.com/hairizuanbinnoorazman/techmeetup/sheets"
+	slidesZ "github.com/hairizuanbinnoorazman/techmeetup/slides"
+	youtubeZ "github.com/hairizuanbinnoorazman/techmeetup/youtube"
 	"golang.org/x/oauth2"
 	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+	"google.golang.org/api/slides/v1"
+	"google.golang.org/api/youtube/v3"
 )
 
 type App struct {
@@ -30,12 +38,25 @@ type App struct {
 	eventMgmtTicker     *time.Ticker
 	authRefresherTicker *time.Ticker
 	calendarSvc         calendarZ.GoogleCalendar
+	youtubeSvc          youtubeZ.Youtube
+	sheetsSvc           sheetsZ.GoogleSheets
+	slidesSvc           slidesZ.GoogleSlides
+	// mu guards the config and the clients, which are replaced by the run loop while syncs
+	// triggered through the server or by changed event files read them
+	mu sync.RWMutex
+	// dirStore is shared between syncs so that the event file watcher can skip the files that
+	// the syncs wrote themselves
+	dirStore *eventstore.DirStore
+	storeMu  sync.Mutex
+	// syncLock prevents overlapping syncs between the ticker and the sync endpoint
+	syncLock chan struct{}
 }
 
 func NewApp(c ConfigStore, l logger.Logger) App {
 	return App{
 		configStore: c,
 		logger:      l,
+		syncLock:    make(chan struct{}, 1),
 	}
 }
 
@@ -43,33 +64,38 @@ func NewApp(c ConfigStore, l logger.Logger) App {
 func (a *App) Initialize() {
 	a.logger.Info("Initialize application")
 	defer a.logger.Info("Application Initialization Complete")
-	a.config, _ = a.configStore.Get()
-	authstore := NewBasicAuthStore(a.config.Authstore)
+	config, _ := a.configStore.Get()
+	authstore := NewBasicAuthStore(config.Authstore)
+	a.mu.Lock()
+	a.config = config
 	a.authStore = &authstore
-	a.eventMgmtTicker = time.NewTicker(time.Duration(a.config.Features.MeetupSync.IdleDuration) * time.Second)
-	if !a.config.Features.MeetupSync.Enabled {
+	a.mu.Unlock()
+	a.eventMgmtTicker = time.NewTicker(time.Duration(config.Features.MeetupSync.IdleDuration) * time.Second)
+	if !config.Features.MeetupSync.Enabled {
 		a.eventMgmtTicker.Stop()
 	}
 	a.authRefresherTicker = time.NewTicker(60 * time.Second)
 	a.RerunAuth()
 }
 
+// RerunAuth reloads the config and sets up the clients again with the tokens in the authstore.
+// Syncs that are running keep the config and clients they started with
 func (a *App) RerunAuth() {
-	a.config, _ = a.configStore.Get()
-	authstore := NewBasicAuthStore(a.config.Authstore)
-	a.googleAuth = GoogleAuthRefresher{
+	config, _ := a.configStore.Get()
+	authstore := NewBasicAuthStore(config.Authstore)
+	googleAuth := GoogleAuthRefresher{
 		client:       http.DefaultClient,
 		logger:       a.logger,
 		authStore:    &authstore,
-		clientID:     a.config.Google.ClientID,
-		clientSecret: a.config.Google.ClientSecret,
+		clientID:     config.Google.ClientID,
+		clientSecret: config.Google.ClientSecret,
 	}
-	a.meetupAuth = MeetupAuthRefresher{
+	meetupAuth := MeetupAuthRefresher{
 		client:       http.DefaultClient,
 		logger:       a.logger,
 		authStore:    &authstore,
-		clientID:     a.config.Meetup.ClientID,
-		clientSecret: a.config.Meetup.ClientSecret,
+		clientID:     config.Meetup.ClientID,
+		clientSecret: config.Meetup.ClientSecret,
 	}
 
 	m, _ := authstore.GetGoogleToken()
@@ -79,33 +105,157 @@ func (a *App) RerunAuth() {
 	}
 	client := oauth2.NewClient(context.TODO(), oauth2.StaticTokenSource(&token))
 	aa, _ := calendar.NewService(context.TODO(), option.WithHTTPClient(client))
-	a.calendarSvc = calendarZ.NewGoogleCalendar(aa, a.logger)
+	yy, _ := youtube.NewService(context.TODO(), option.WithHTTPClient(client))
+	ss, _ := sheets.NewService(context.TODO(), option.WithHTTPClient(client))
+	sl, _ := slides.NewService(context.TODO(), option.WithHTTPClient(client))
+	dd, _ := drive.NewService(context.TODO(), option.WithHTTPClient(client))
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.config = config
+	a.googleAuth = googleAuth
+	a.meetupAuth = meetupAuth
+	a.calendarSvc = calendarZ.NewGoogleCalendar(aa, a.logger, config.CalendarConfig.SendUpdates)
+	a.youtubeSvc = youtubeZ.NewYoutube(yy, a.logger, "")
+	a.sheetsSvc = sheetsZ.NewGoogleSheets(a.logger, ss)
+	a.slidesSvc = slidesZ.NewGoogleSlides(a.logger, sl, dd)
+}
+
+// currentConfig returns a copy of the config that is safe to read while the run loop reloads it
+func (a *App) currentConfig() Config {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.config
+}
+
+// MeetupClient sets up the meetup client with the meetup token in the authstore
+func (a *App) MeetupClient() eventmgmt.Meetup {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.meetupClient()
+}
+
+func (a *App) meetupClient() eventmgmt.Meetup {
+	authstore := NewBasicAuthStore(a.config.Authstore)
+	m, err := authstore.GetMeetupToken()
+	if err != nil {
+		a.logger.Errorf("Unable to retrieve meetup token. %v", err)
+	}
+	return eventmgmt.NewMeetup(a.logger, http.DefaultClient, a.config.MeetupConfig.MeetupGroup, m.AccessToken, a.config.MeetupConfig.OrganizerMapping)
+}
+
+// StreamyardClient sets up the streamyard client with the streamyard credentials in config
+func (a *App) StreamyardClient() streaming.Streamyard {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.streamyardClient()
+}
+
+func (a *App) streamyardClient() streaming.Streamyard {
+	return streaming.NewStreamyard(a.logger, http.DefaultClient, a.config.Streamyard.CSRFToken, a.config.Streamyard.JWT, a.config.StreamyardConfig.UserID, a.config.StreamyardConfig.YoutubeDestination, a.config.StreamyardConfig.FacebookGroupDestination)
 }
 
-func (a *App) Run(notifyConfigChange chan bool, interrupts chan os.Signal) {
+// NewStore sets up the store of the store type. The path of the sheets eventstore is the ID of
+// the spreadsheet, which is read with the google token in the authstore. The directory eventstore
+// is reused for as long as the directory stays the same
+func (a *App) NewStore(storeType, path string) (eventstore.Store, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.newStore(storeType, path)
+}
+
+func (a *App) newStore(storeType, path string) (eventstore.Store, error) {
+	if storeType == "dir" {
+		a.storeMu.Lock()
+		defer a.storeMu.Unlock()
+		if a.dirStore == nil || a.dirStore.Dir() != path {
+			a.dirStore = eventstore.NewDirStore(path)
+		}
+		return a.dirStore, nil
+	}
+	if storeType != "sheets" {
+		return eventstore.NewStore(storeType, path)
+	}
+	loc, err := a.config.Location()
+	if err != nil {
+		return nil, err
+	}
+	return eventstore.NewSheetsStore(a.logger, a.sheetsSvc, path, a.config.EventStoreSheet, loc), nil
+}
+
+// NewEventStore wires up the eventstore along with the clients to the various platforms
+// based on the current config and auth tokens. The options are applied after the ones from config
+func (a *App) NewEventStore(opts ...eventstore.Option) (eventstore.EventStore, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	meetupClient := a.meetupClient()
+	streamyardClient := a.streamyardClient()
+	store, err := a.newStore(a.config.EventStoreType, a.config.EventStoreFile)
+	if err != nil {
+		return eventstore.EventStore{}, err
+	}
+	loc, err := a.config.Location()
+	if err != nil {
+		return eventstore.EventStore{}, err
+	}
+	speakers, err := a.config.Speakers()
+	if err != nil {
+		return eventstore.EventStore{}, err
+	}
+	configOpts := []eventstore.Option{
+		eventstore.WithBannerGeneration(a.config.BannerConfig.OutputDir, a.config.BannerConfig.Template),
+		eventstore.WithTimeZone(loc),
+		eventstore.WithFreezeWindow(a.config.Features.MeetupSync.FreezeWindow),
+		eventstore.WithSeries(a.config.Series),
+		eventstore.WithDescriptionTemplates(a.config.DescriptionTemplates),
+		eventstore.WithVenues(a.config.Venues),
+		eventstore.WithSpeakers(speakers),
+		eventstore.WithWorkers(a.config.SyncConfig.Workers),
+		eventstore.WithSyncTimeout(a.config.SyncConfig.Timeout),
+		eventstore.WithYoutube(a.youtubeSvc),
+		eventstore.WithSheetsReporter(a.sheetsSvc, a.config.SpreadsheetStats),
+		eventstore.WithSlides(a.slidesSvc, a.config.SlidesConfig.TemplateID, a.config.SlidesConfig.FolderID),
+	}
+	if a.config.AuditLog != "" {
+		configOpts = append(configOpts, eventstore.WithAuditLog(eventstore.NewAuditLog(a.config.AuditLog)))
+	}
+	return eventstore.NewEventStore(a.logger, meetupClient, a.calendarSvc, streamyardClient, store, a.config.CalendarConfig.CalendarID, a.config.CalendarConfig.CalendarEventInvitation, a.config.Features.MeetupSync.SubFeatures,
+		append(configOpts, opts...)...,
+	), nil
+}
+
+func (a *App) Run(notifyConfigChange chan bool, notifyEventChange chan string, interrupts chan os.Signal) {
 	a.logger.Info("Begin running sync loop")
 	defer a.logger.Info("Sync loop ends")
-	go server(a.config, a.authStore, notifyConfigChange)
+	go server(a.config, a.authStore, notifyConfigChange, syncTrigger{logger: a.logger, app: a}, icalFeed{logger: a.logger, app: a})
 	for {
 		select {
 		case <-notifyConfigChange:
 			a.logger.Warning("Begin running initialization")
 			time.Sleep(1 * time.Second)
 			a.Initialize()
+		case path := <-notifyEventChange:
+			// Syncs are run in the background so that changes written by a running sync are
+			// dropped rather than queued up
+			go a.syncChangedEvent(path)
 		case <-interrupts:
 			a.logger.Warning("Application stopping")
 			time.Sleep(1 * time.Second)
 			os.Exit(1)
 		case <-a.eventMgmtTicker.C:
 			a.logger.Info("Begin event syncing")
-			m, err := a.authStore.GetMeetupToken()
+			if !a.lockSync() {
+				a.logger.Warning("Skipping event syncing as a sync is already in progress")
+				continue
+			}
+			s, err := a.NewEventStore()
 			if err != nil {
-				a.logger.Errorf("Unable to retrieve meetup token. %v", err)
+				a.logger.Errorf("Unable to setup eventstore. %v", err)
+				a.unlockSync()
+				continue
 			}
-			meetupClient := eventmgmt.NewMeetup(a.logger, http.DefaultClient, a.config.MeetupConfig.MeetupGroup, m.AccessToken, a.config.MeetupConfig.OrganizerMapping)
-			streamyardClient := streaming.NewStreamyard(a.logger, http.DefaultClient, a.config.Streamyard.CSRFToken, a.config.Streamyard.JWT, a.config.StreamyardConfig.UserID, a.config.StreamyardConfig.YoutubeDestination, a.config.StreamyardConfig.FacebookGroupDestination)
-			s := eventstore.NewEventStore(a.logger, meetupClient, a.calendarSvc, streamyardClient, a.config.EventStoreFile, a.config.CalendarConfig.CalendarID, a.config.CalendarConfig.CalendarEventInvitation, a.config.Features.MeetupSync.SubFeatures)
 			err = s.CheckEvents(time.Now())
+			a.unlockSync()
 
 			if err != nil {
 				a.logger.Errorf("Issue when checking events. %v", err)
@@ -123,7 +273,7 @@ func (a *App) Run(notifyConfigChange chan bool, interrupts chan os.Signal) {
 				a.logger.Errorf("Unable to refresh Meetup Access Tokens. Err: %v", err)
 			}
 			// Doublecheck for streamyard jwt token expiration
-			err = streaming.JWTChecker(a.logger, a.config.Streamyard.JWT)
+			err = streaming.JWTChecker(a.logger, a.currentConfig().Streamyard.JWT)
 			if err != nil {
 				a.logger.Errorf("Do check streamyard login creds to ensure no further issues with automation. Err: %v", err)
 			}
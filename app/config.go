@@ -1,7 +1,9 @@
 package app
 
 import (
+	"fmt"
 	"io/ioutil"
+	"time"
 
 	"github.com/hairizuanbinnoorazman/techmeetup/eventstore"
 
@@ -38,14 +40,50 @@ func (b BasicConfigStore) Get() (Config, error) {
 type Config struct {
 	Authstore        string                `yaml:"authstore"`
 	EventStoreFile   string                `yaml:"eventstore"`
+	EventStoreType   string                `yaml:"eventstore_type"`
+	TimeZone         string                `yaml:"timezone"`
 	Features         Features              `yaml:"features"`
 	Meetup           MeetupCredentials     `yaml:"meetup_credentials"`
 	Google           GoogleCredentials     `yaml:"google_credentials"`
 	Streamyard       StreamyardCredentials `yaml:"streamyard_credentials"`
-	SpreadsheetStats string                `yaml:"spreadsheet_stats"`
+	SpreadsheetStats string                `yaml:"spreadsheet_stats"` // ID of the spreadsheet maintained by the sheets reporter
 	CalendarConfig   CalendarConfig        `yaml:"calendar_config"`
 	MeetupConfig     MeetupConfig          `yaml:"meetup_config"`
 	StreamyardConfig StreamyardConfig      `yaml:"streamyard_config"`
+	BannerConfig     BannerConfig          `yaml:"banner_config"`
+	SlidesConfig     SlidesConfig          `yaml:"slides_config"`
+	ServerConfig     ServerConfig          `yaml:"server_config"`
+	SyncConfig       SyncConfig            `yaml:"sync_config"`
+	AuditLog         string                `yaml:"audit_log"`
+	Series           []eventstore.Series   `yaml:"series"`
+	Venues           []eventstore.Venue    `yaml:"venues"`
+	// DescriptionTemplates renders descriptions per platform from the agenda and speakers of events
+	DescriptionTemplates eventstore.DescriptionTemplates `yaml:"description_templates"`
+	// SpeakersFile is the speakers registry that agenda items refer to by speaker ID
+	SpeakersFile string `yaml:"speakers_file"`
+	// EventStoreSheet is the name of the sheet with the events for the sheets eventstore. Defaults
+	// to the first sheet of the spreadsheet
+	EventStoreSheet string `yaml:"eventstore_sheet"`
+}
+
+// Location is the default time zone for events. Defaults to Asia/Singapore if not set
+func (c Config) Location() (*time.Location, error) {
+	if c.TimeZone == "" {
+		return time.LoadLocation("Asia/Singapore")
+	}
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load timezone from config. Err: %v", err)
+	}
+	return loc, nil
+}
+
+// Speakers are the speakers in the speakers registry. There are no speakers if it is not set
+func (c Config) Speakers() ([]eventstore.Speaker, error) {
+	if c.SpeakersFile == "" {
+		return []eventstore.Speaker{}, nil
+	}
+	return eventstore.LoadSpeakers(c.SpeakersFile)
 }
 
 type Features struct {
@@ -62,6 +100,8 @@ type MeetupFeatureControl struct {
 	Enabled      bool                               `yaml:"enabled"`
 	IdleDuration int                                `yaml:"idle_duration"`
 	SubFeatures  eventstore.SubMeetupFeatureControl `yaml:"subfeatures"`
+	// FreezeWindow is the default freeze window for all events
+	FreezeWindow eventstore.FreezeWindow `yaml:"freeze_window"`
 }
 
 type MeetupCredentials struct {
@@ -85,6 +125,8 @@ type StreamyardCredentials struct {
 type CalendarConfig struct {
 	CalendarID              string `yaml:"calendar_id"`
 	CalendarEventInvitation string `yaml:"calendar_event_invitation"`
+	// SendUpdates is who gets notified of calendar invite changes: all, externalOnly or none
+	SendUpdates string `yaml:"send_updates"`
 }
 
 type MeetupConfig struct {
@@ -97,3 +139,30 @@ type StreamyardConfig struct {
 	YoutubeDestination       string `yaml:"youtube_destination"`
 	FacebookGroupDestination string `yaml:"facebook_group_destination"`
 }
+
+type BannerConfig struct {
+	// OutputDir is the folder where generated banner images are saved
+	OutputDir string `yaml:"output_dir"`
+	// Template is the html template that is rendered into the banner image
+	Template string `yaml:"template"`
+}
+
+type SlidesConfig struct {
+	// TemplateID is the ID of the presentation that the slides of each event are copied from
+	TemplateID string `yaml:"template_id"`
+	// FolderID is the drive folder where the slides are created. Defaults to the folder of the template
+	FolderID string `yaml:"folder_id"`
+}
+
+type ServerConfig struct {
+	// SyncToken is the bearer token needed to trigger a sync via the /sync endpoint.
+	// The endpoint is disabled if it is not set
+	SyncToken string `yaml:"sync_token"`
+}
+
+type SyncConfig struct {
+	// Workers is the number of events that are synced concurrently. Defaults to 1
+	Workers int `yaml:"workers"`
+	// Timeout is the deadline of each sync run, e.g. 10m. No deadline if not set
+	Timeout time.Duration `yaml:"timeout"`
+}
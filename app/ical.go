@@ -0,0 +1,38 @@
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+)
+
+// icalFeed serves the public events in the eventstore as an iCalendar feed that members are able
+// to subscribe to
+//
+//	GET /calendar.ics
+type icalFeed struct {
+	logger logger.Logger
+	app    *App
+}
+
+func (f icalFeed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	s, err := f.app.NewEventStore()
+	if err != nil {
+		f.logger.Errorf("Unable to create eventstore for calendar feed. Err: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	feed, err := s.ICalendar(time.Now())
+	if err != nil {
+		f.logger.Errorf("Unable to generate calendar feed. Err: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Write([]byte(feed))
+}
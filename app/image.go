@@ -4,32 +4,44 @@ import (
 	"html/template"
 	"log"
 	"net/http"
+	"path/filepath"
 )
 
 type webinarImageData struct {
 	SeriesName   string
 	WebinarTitle string
 	WebinarDate  string
+	Speakers     string
 }
 
-type image struct{}
+type image struct {
+	templatePath string
+}
 
 func (i image) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	seriesName := r.URL.Query().Get("series_name")
 	webinarTitle := r.URL.Query().Get("webinar_title")
 	webinarDate := r.URL.Query().Get("webinar_date")
+	speakers := r.URL.Query().Get("speakers")
 
-	tmpl, err := template.New("").ParseFiles("./templates/rocket_image.html")
+	templatePath := i.templatePath
+	if templatePath == "" {
+		templatePath = "./templates/rocket_image.html"
+	}
+	tmpl, err := template.New("").ParseFiles(templatePath)
 	if err != nil {
 		log.Printf("Error in parsing template. Err: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
 	data := webinarImageData{
 		SeriesName:   seriesName,
 		WebinarTitle: webinarTitle,
 		WebinarDate:  webinarDate,
+		Speakers:     speakers,
 	}
 
-	err = tmpl.ExecuteTemplate(w, "rocket_image.html", data)
+	err = tmpl.ExecuteTemplate(w, filepath.Base(templatePath), data)
 	if err != nil {
 		log.Printf("Error in parsing template. ERr: %v", err)
 	}
@@ -3,12 +3,15 @@ package app
 import (
 	"log"
 	"net/http"
+	"path/filepath"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/eventstore"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/fsnotify.v1"
 )
 
-func server(c Config, a AuthStore, notifyConfigChange chan bool) {
+func server(c Config, a AuthStore, notifyConfigChange chan bool, sync syncTrigger, feed icalFeed) {
 	meetupAuthorize := MeetupAuthorize{
 		client:      http.DefaultClient,
 		logger:      logrus.New(),
@@ -41,23 +44,34 @@ func server(c Config, a AuthStore, notifyConfigChange chan bool) {
 		notifyConfigChange: notifyConfigChange,
 	}
 
-	http.Handle("/image", image{})
+	http.Handle("/image", image{templatePath: c.BannerConfig.Template})
 	http.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("./assets"))))
 	http.Handle("/auth/meetup/authorize", meetupAuthorize)
 	http.Handle("/auth/meetup/access", meetupAccess)
 	http.Handle("/auth/google/authorize", googleAuthorize)
 	http.Handle("/auth/google/access", googleAccess)
+	http.Handle("/sync", sync)
+	http.Handle("/calendar.ics", feed)
 	http.Handle("/", index{})
 	log.Fatal(http.ListenAndServe(":9000", nil))
 }
 
-func ConfigFileWatcher(watcher *fsnotify.Watcher, notifyConfigChange chan bool) {
+// ConfigFileWatcher notifies of changes to the config file. For directory eventstores, the
+// directory is watched as well and the changed event files are notified separately
+func ConfigFileWatcher(watcher *fsnotify.Watcher, notifyConfigChange chan bool, eventDir string, notifyEventChange chan string) {
 	for {
 		select {
 		case event, ok := <-watcher.Events:
 			if !ok {
 				return
 			}
+			if eventDir != "" && filepath.Dir(event.Name) == filepath.Clean(eventDir) {
+				// Editors and the eventstore itself replace files by renaming over them
+				if event.Op&(fsnotify.Write|fsnotify.Create) != 0 && eventstore.IsEventFile(event.Name) {
+					notifyEventChange <- event.Name
+				}
+				continue
+			}
 			if event.Op&fsnotify.Write == fsnotify.Write {
 				notifyConfigChange <- true
 			}
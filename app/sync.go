@@ -0,0 +1,152 @@
+package app
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/eventstore"
+	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+)
+
+var errSyncInProgress = errors.New("A sync is already in progress. Please try again later")
+
+// syncTrigger allows users to force a sync right now rather than waiting for the ticker
+//
+//	POST /sync                                  - sync all events on all platforms
+//	POST /sync?event=<id or title>              - sync a single event
+//	POST /sync?event=<id or title>&platform=x   - sync a single event on a single platform
+//	POST /sync?dry_run=true                     - only return the plan
+//
+// Requests need to provide the sync token in config as a bearer token
+type syncTrigger struct {
+	logger logger.Logger
+	app    *App
+}
+
+type syncErrorResp struct {
+	Error string `json:"error"`
+}
+
+func (s syncTrigger) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeJSON(w, http.StatusMethodNotAllowed, syncErrorResp{Error: "Only POST is allowed"})
+		return
+	}
+	if !s.authorized(r) {
+		s.writeJSON(w, http.StatusUnauthorized, syncErrorResp{Error: "Invalid or missing sync token"})
+		return
+	}
+
+	opts := eventstore.SyncOptions{
+		DryRun:   r.URL.Query().Get("dry_run") == "true",
+		Event:    r.URL.Query().Get("event"),
+		Platform: r.URL.Query().Get("platform"),
+	}
+	// The sync is not tied to the request as a client that disconnects would otherwise abort a
+	// half applied sync. The sync timeout in config still bounds it
+	p, err := s.app.Sync(context.Background(), opts)
+	switch {
+	case err == errSyncInProgress:
+		s.writeJSON(w, http.StatusConflict, syncErrorResp{Error: err.Error()})
+	case err == eventstore.ErrEventNotFound:
+		s.writeJSON(w, http.StatusNotFound, syncErrorResp{Error: err.Error()})
+	case err == eventstore.ErrUnknownPlatform:
+		s.writeJSON(w, http.StatusBadRequest, syncErrorResp{Error: err.Error()})
+	case err != nil:
+		s.logger.Errorf("Unable to run triggered sync. Err: %v", err)
+		s.writeJSON(w, http.StatusInternalServerError, syncErrorResp{Error: err.Error()})
+	default:
+		s.writeJSON(w, http.StatusOK, p)
+	}
+}
+
+func (s syncTrigger) authorized(r *http.Request) bool {
+	token := s.app.currentConfig().ServerConfig.SyncToken
+	if token == "" {
+		s.logger.Warning("Sync token is not set in config. Sync endpoint is disabled")
+		return false
+	}
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return false
+	}
+	provided := strings.TrimPrefix(header, "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
+
+func (s syncTrigger) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(raw)
+}
+
+// Sync runs a sync of the eventstore unless another sync (e.g. from the ticker) is already
+// running. Dry run mode in config would always only compute the plan
+func (a *App) Sync(ctx context.Context, opts eventstore.SyncOptions) (eventstore.Plan, error) {
+	if !a.lockSync() {
+		return eventstore.Plan{}, errSyncInProgress
+	}
+	defer a.unlockSync()
+
+	s, err := a.NewEventStore()
+	if err != nil {
+		return eventstore.Plan{}, err
+	}
+	if a.currentConfig().Features.MeetupSync.SubFeatures.DryRunMode {
+		opts.DryRun = true
+	}
+	return s.Sync(ctx, opts)
+}
+
+// syncChangedEvent syncs the event in the changed file of a directory eventstore. Files that
+// were last written by a sync, e.g. to record the sync status, are skipped as syncing them again
+// would only write them again. It returns true if a sync was run
+func (a *App) syncChangedEvent(path string) bool {
+	if !a.currentConfig().Features.MeetupSync.Enabled {
+		return false
+	}
+	a.storeMu.Lock()
+	dirStore := a.dirStore
+	a.storeMu.Unlock()
+	if dirStore != nil && dirStore.WroteLast(path) {
+		a.logger.Debugf("Skipping event file %v as it was written by the eventstore", path)
+		return false
+	}
+	e, err := eventstore.ReadEventFile(path)
+	if err != nil {
+		a.logger.Errorf("Unable to read changed event file %v. Err: %v", path, err)
+		return false
+	}
+	a.logger.Infof("Event file %v changed. Begin syncing event: %v", path, e.ID)
+	_, err = a.Sync(context.Background(), eventstore.SyncOptions{Event: e.ID})
+	switch {
+	case err == errSyncInProgress:
+		a.logger.Infof("Skipping sync of event %v as a sync is already in progress", e.ID)
+	case err != nil:
+		a.logger.Errorf("Unable to sync changed event %v. Err: %v", e.ID, err)
+	}
+	return err != errSyncInProgress
+}
+
+// lockSync returns false if a sync is already running
+func (a *App) lockSync() bool {
+	select {
+	case a.syncLock <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (a *App) unlockSync() {
+	<-a.syncLock
+}
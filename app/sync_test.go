@@ -0,0 +1,152 @@
+package app
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/eventstore"
+	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+	fsnotify "gopkg.in/fsnotify.v1"
+)
+
+func TestSyncTrigger_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		header     string
+		syncToken  string
+		locked     bool
+		wantStatus int
+	}{
+		{
+			name:       "Wrong method",
+			method:     http.MethodGet,
+			header:     "Bearer abc",
+			syncToken:  "abc",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "Sync token not configured",
+			method:     http.MethodPost,
+			header:     "Bearer ",
+			syncToken:  "",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Wrong token",
+			method:     http.MethodPost,
+			header:     "Bearer xyz",
+			syncToken:  "abc",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Token without bearer prefix",
+			method:     http.MethodPost,
+			header:     "abc",
+			syncToken:  "abc",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "Sync already running",
+			method:     http.MethodPost,
+			header:     "Bearer abc",
+			syncToken:  "abc",
+			locked:     true,
+			wantStatus: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewApp(BasicConfigStore{}, logger.LoggerForTests{Tester: t})
+			a.config.ServerConfig.SyncToken = tt.syncToken
+			if tt.locked {
+				a.lockSync()
+			}
+			s := syncTrigger{logger: logger.LoggerForTests{Tester: t}, app: &a}
+			req := httptest.NewRequest(tt.method, "/sync", nil)
+			req.Header.Set("Authorization", tt.header)
+			w := httptest.NewRecorder()
+			s.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("syncTrigger.ServeHTTP() status = %v, want %v. Body: %v", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestApp_SyncChangedEvent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events")
+	if err != nil {
+		t.Fatalf("Unable to create temp dir. Err: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "webinar-80.yaml")
+	// The banner template is missing so that the sync records an error in the event file
+	ioutil.WriteFile(path, []byte(`track_event: true
+start_date: "`+time.Now().AddDate(0, 1, 0).Format(time.RFC3339)+`"
+title: Webinar 80 - Tracing
+description: Some description
+duration: 90
+is_online: true
+generate_banner_image: true
+organizers:
+- name: Organizer
+  email: organizer@example.com
+`), 0644)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		t.Fatalf("Unable to create watcher. Err: %v", err)
+	}
+	t.Cleanup(func() { watcher.Close() })
+	watcher.Add(dir)
+	notifyEventChange := make(chan string, 10)
+	go ConfigFileWatcher(watcher, make(chan bool, 10), dir, notifyEventChange)
+
+	a := NewApp(BasicConfigStore{}, logger.LoggerForTests{Tester: t})
+	a.config.EventStoreType = "dir"
+	a.config.EventStoreFile = dir
+	a.config.Features.MeetupSync.Enabled = true
+	a.config.Features.MeetupSync.SubFeatures.GenerateBannerImageSync = true
+	a.config.BannerConfig.Template = filepath.Join(dir, "missing.html")
+
+	_, err = a.Sync(context.TODO(), eventstore.SyncOptions{})
+	if err != nil {
+		t.Fatalf("App.Sync() error = %v", err)
+	}
+	e, err := eventstore.ReadEventFile(path)
+	if err != nil || e.SyncStatus["banner"].LastError == "" {
+		t.Fatalf("App.Sync() = %+v, want the sync status to be written. Err: %v", e.SyncStatus, err)
+	}
+
+	// The watcher reports the file written by the sync but it should not be synced again.
+	// Syncing it would write the file again which would be reported again
+	reported := 0
+	for done := false; !done; {
+		select {
+		case changed := <-notifyEventChange:
+			reported++
+			if a.syncChangedEvent(changed) {
+				t.Fatalf("App.syncChangedEvent(%v) synced the file written by the sync", changed)
+			}
+		case <-time.After(time.Second):
+			done = true
+		}
+	}
+	if reported == 0 {
+		t.Fatalf("Watcher did not report the file written by the sync")
+	}
+
+	// Changes made by organizers are synced
+	raw, _ := ioutil.ReadFile(path)
+	ioutil.WriteFile(path, append(raw, []byte("# Edited by an organizer\n")...), 0644)
+	if !a.syncChangedEvent(path) {
+		t.Errorf("App.syncChangedEvent(%v) did not sync the file changed by an organizer", path)
+	}
+}
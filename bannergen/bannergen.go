@@ -11,7 +11,7 @@ import (
 	"github.com/chromedp/chromedp"
 )
 
-func Generate_banner(outputPath, seriesName, webinarTitle, webinarDate string) error {
+func Generate_banner(outputPath, seriesName, webinarTitle, webinarDate, speakers string) error {
 	// create context
 	ctx, cancel := chromedp.NewContext(context.Background())
 	defer cancel()
@@ -22,6 +22,7 @@ func Generate_banner(outputPath, seriesName, webinarTitle, webinarDate string) e
 	val.Add("series_name", seriesName)
 	val.Add("webinar_title", webinarTitle)
 	val.Add("webinar_date", webinarDate)
+	val.Add("speakers", speakers)
 	bannerEndpoint.RawQuery = val.Encode()
 	var buf []byte
 	if err := chromedp.Run(ctx, elementScreenshot(bannerEndpoint.String(), `#banner`, &buf)); err != nil {
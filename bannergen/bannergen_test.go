@@ -8,6 +8,7 @@ func Test_Generate_banner(t *testing.T) {
 		seriesName   string
 		webinarTitle string
 		webinarDate  string
+		speakers     string
 	}
 	tests := []struct {
 		name string
@@ -20,12 +21,13 @@ func Test_Generate_banner(t *testing.T) {
 				seriesName:   "Webinar #78",
 				webinarTitle: "This is a test of a webinar",
 				webinarDate:  "21st May 2020 - 7.30pm to 9.00pm",
+				speakers:     "Jane Doe, John Doe",
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			Generate_banner(tt.args.outputPath, tt.args.seriesName, tt.args.webinarTitle, tt.args.webinarDate)
+			Generate_banner(tt.args.outputPath, tt.args.seriesName, tt.args.webinarTitle, tt.args.webinarDate, tt.args.speakers)
 		})
 	}
 }
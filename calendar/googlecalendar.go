@@ -17,17 +17,31 @@ type CalendarEvent struct {
 	Description string
 	Duration    float64
 	Attendees   []string
+	// TimeZone is an IANA time zone name, e.g. Asia/Singapore. If empty, the offset of
+	// the start and end time is used
+	TimeZone string
+	// Status is confirmed, tentative or cancelled
+	Status string
+	// Location is the free form address of the event. Empty for online events
+	Location string
 }
 
 type GoogleCalendar struct {
 	calendarSvc *calendar.Service
 	logger      logger.Logger
+	// sendUpdates controls who gets notified of changes to calendar invites.
+	// Can be one of all, externalOnly or none
+	sendUpdates string
 }
 
-func NewGoogleCalendar(calendarSvc *calendar.Service, logger logger.Logger) GoogleCalendar {
+func NewGoogleCalendar(calendarSvc *calendar.Service, logger logger.Logger, sendUpdates string) GoogleCalendar {
+	if sendUpdates == "" {
+		sendUpdates = "none"
+	}
 	return GoogleCalendar{
 		calendarSvc: calendarSvc,
 		logger:      logger,
+		sendUpdates: sendUpdates,
 	}
 }
 
@@ -38,13 +52,13 @@ func (g *GoogleCalendar) GetEvent(ctx context.Context, calendarID, eventID strin
 	if err != nil {
 		return CalendarEvent{}, fmt.Errorf("Unable to retrieve calendar event. Err: %v", err)
 	}
-	startTime, err := time.Parse("2006-01-02T15:04:05-07:00", resp.Start.DateTime)
+	startTime, err := time.Parse(time.RFC3339, resp.Start.DateTime)
 	if err != nil {
 		return CalendarEvent{}, fmt.Errorf("Unable to parse start time of calendar event. Err: %v", err)
 	}
-	endTime, err := time.Parse("2006-01-02T15:04:05-07:00", resp.End.DateTime)
+	endTime, err := time.Parse(time.RFC3339, resp.End.DateTime)
 	if err != nil {
-		return CalendarEvent{}, fmt.Errorf("Unable to parse start time of calendar event. Err: %v", err)
+		return CalendarEvent{}, fmt.Errorf("Unable to parse end time of calendar event. Err: %v", err)
 	}
 	attendees := []string{}
 	for _, z := range resp.Attendees {
@@ -59,6 +73,9 @@ func (g *GoogleCalendar) GetEvent(ctx context.Context, calendarID, eventID strin
 		Description: resp.Description,
 		Duration:    duration.Minutes(),
 		Attendees:   attendees,
+		TimeZone:    resp.Start.TimeZone,
+		Status:      resp.Status,
+		Location:    resp.Location,
 	}, nil
 }
 
@@ -76,18 +93,20 @@ func (g *GoogleCalendar) CreateEvent(ctx context.Context, calendarID string, c C
 	e := calendar.Event{
 		Summary:     c.Title,
 		Description: c.Description,
+		Location:    c.Location,
 		Start: &calendar.EventDateTime{
 			DateTime: c.StartTime.Format("2006-01-02T15:04:05Z07:00"),
-			TimeZone: "Asia/Singapore",
+			TimeZone: c.TimeZone,
 		},
 		End: &calendar.EventDateTime{
 			DateTime: c.EndTime.Format("2006-01-02T15:04:05Z07:00"),
-			TimeZone: "Asia/Singapore",
+			TimeZone: c.TimeZone,
 		},
 		Attendees: attendees,
 	}
 	eventCreateReq := g.calendarSvc.Events.Insert(calendarID, &e)
 	eventCreateReq = eventCreateReq.Context(ctx)
+	eventCreateReq = eventCreateReq.SendUpdates(g.sendUpdates)
 	resp, err := eventCreateReq.Do()
 	if err != nil {
 		return CalendarEvent{}, fmt.Errorf("Unable to create event. Err: %v", err)
@@ -97,6 +116,31 @@ func (g *GoogleCalendar) CreateEvent(ctx context.Context, calendarID string, c C
 }
 
 func (g *GoogleCalendar) UpdateEvent(ctx context.Context, calendarID string, c CalendarEvent) (CalendarEvent, error) {
+	return g.updateEvent(ctx, calendarID, c, g.sendUpdates)
+}
+
+// RescheduleEvent updates the event similar to UpdateEvent but all attendees are always
+// notified of the change
+func (g *GoogleCalendar) RescheduleEvent(ctx context.Context, calendarID string, c CalendarEvent) (CalendarEvent, error) {
+	return g.updateEvent(ctx, calendarID, c, "all")
+}
+
+// CancelEvent cancels the calendar event and notifies all attendees of the cancellation
+func (g *GoogleCalendar) CancelEvent(ctx context.Context, calendarID, eventID string) error {
+	if eventID == "" {
+		return fmt.Errorf("Event ID is missing. Please provide it")
+	}
+	eventDeleteReq := g.calendarSvc.Events.Delete(calendarID, eventID)
+	eventDeleteReq = eventDeleteReq.Context(ctx)
+	eventDeleteReq = eventDeleteReq.SendUpdates("all")
+	err := eventDeleteReq.Do()
+	if err != nil {
+		return fmt.Errorf("Unable to cancel event. Err: %v", err)
+	}
+	return nil
+}
+
+func (g *GoogleCalendar) updateEvent(ctx context.Context, calendarID string, c CalendarEvent, sendUpdates string) (CalendarEvent, error) {
 	if c.StartTime.IsZero() || c.EndTime.IsZero() || c.Title == "" || c.Description == "" {
 		return CalendarEvent{}, fmt.Errorf("Issue with input calendar event")
 	}
@@ -110,22 +154,23 @@ func (g *GoogleCalendar) UpdateEvent(ctx context.Context, calendarID string, c C
 	e := calendar.Event{
 		Summary:     c.Title,
 		Description: c.Description,
+		Location:    c.Location,
 		Start: &calendar.EventDateTime{
 			DateTime: c.StartTime.Format("2006-01-02T15:04:05Z07:00"),
-			TimeZone: "Asia/Singapore",
+			TimeZone: c.TimeZone,
 		},
 		End: &calendar.EventDateTime{
 			DateTime: c.EndTime.Format("2006-01-02T15:04:05Z07:00"),
-			TimeZone: "Asia/Singapore",
+			TimeZone: c.TimeZone,
 		},
 		Attendees: attendees,
 	}
 	eventCreateReq := g.calendarSvc.Events.Update(calendarID, c.ID, &e)
 	eventCreateReq = eventCreateReq.Context(ctx)
-	eventCreateReq = eventCreateReq.SendUpdates("none")
+	eventCreateReq = eventCreateReq.SendUpdates(sendUpdates)
 	resp, err := eventCreateReq.Do()
 	if err != nil {
-		return CalendarEvent{}, fmt.Errorf("Unable to create event. Err: %v", err)
+		return CalendarEvent{}, fmt.Errorf("Unable to update event. Err: %v", err)
 	}
 	c.ID = resp.Id
 	return c, nil
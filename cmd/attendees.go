@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/eventmgmt"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	attendeesCmd = func() *cobra.Command {
+		attendeescmd := &cobra.Command{
+			Use:   "attendees",
+			Short: "Attendees of events in the eventstore",
+			Long:  ``,
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Help()
+			},
+		}
+		attendeescmd.AddCommand(exportAttendeesCmd())
+		return attendeescmd
+	}
+
+	exportAttendeesCmd = func() *cobra.Command {
+		var configFile string
+		var format string
+		var output string
+		var responses []string
+		exportattendeescmd := &cobra.Command{
+			Use:   "export [Event ID or title]",
+			Short: "Export the RSVPs of an event on meetup as csv or json",
+			Long: `
+This utility lists the RSVPs of the event on meetup, e.g. to prepare name tags or to share the
+number of attendees with the venue host. The csv has a column for each RSVP question. The json
+includes a summary of the RSVPs by response; attendees are the members that are going along with
+their guests. RSVPs of all responses (yes, no, waitlist) are exported unless --response is set.`,
+			Args: cobra.ExactArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				if format != "csv" && format != "json" {
+					logrus.Errorf("Unknown format %v. Format can only be csv or json", format)
+					os.Exit(1)
+				}
+				for _, r := range responses {
+					if r != eventmgmt.RSVPYes && r != eventmgmt.RSVPNo && r != eventmgmt.RSVPWaitlist {
+						logrus.Errorf("Unknown response %v. Response can only be yes, no or waitlist", r)
+						os.Exit(1)
+					}
+				}
+				s := eventStoreHelper(configFile)
+				e, rsvps, err := s.RSVPs(context.Background(), args[0], responses...)
+				if err != nil {
+					logrus.Errorf("Unable to retrieve rsvps. Err: %v", err)
+					os.Exit(1)
+				}
+				summary := eventmgmt.SummarizeRSVPs(rsvps)
+
+				var buf bytes.Buffer
+				if format == "json" {
+					raw, _ := json.MarshalIndent(struct {
+						EventID  string                `json:"event_id"`
+						Title    string                `json:"title"`
+						MeetupID string                `json:"meetup_id"`
+						Summary  eventmgmt.RSVPSummary `json:"summary"`
+						RSVPs    []eventmgmt.RSVP      `json:"rsvps"`
+					}{e.ID, e.Title, e.MeetupID, summary, rsvps}, "", "  ")
+					buf.Write(raw)
+					buf.WriteString("\n")
+				} else {
+					err = eventmgmt.WriteRSVPsCSV(&buf, rsvps)
+					if err != nil {
+						logrus.Errorf("Unable to write rsvps as csv. Err: %v", err)
+						os.Exit(1)
+					}
+				}
+				logrus.Infof("RSVPs of %v - yes: %v, no: %v, waitlist: %v, guests: %v, attendees: %v", e.Title, summary.Yes, summary.No, summary.Waitlist, summary.Guests, summary.Attendees)
+				if output == "" {
+					fmt.Print(buf.String())
+					return
+				}
+				err = ioutil.WriteFile(output, buf.Bytes(), 0644)
+				if err != nil {
+					logrus.Errorf("Unable to write rsvps. Err: %v", err)
+					os.Exit(1)
+				}
+				logrus.Infof("RSVPs written to %v", output)
+			},
+		}
+		exportattendeescmd.Flags().StringVar(&configFile, "config", "config.yaml", "Configuration file. Please utilize the fetcher to ensure the right format of config is used")
+		exportattendeescmd.Flags().StringVar(&format, "format", "csv", "Output format. Either csv or json")
+		exportattendeescmd.Flags().StringVar(&output, "output", "", "Path of the file to write. Prints the rsvps if empty")
+		exportattendeescmd.Flags().StringSliceVar(&responses, "response", []string{}, "Only export rsvps with these responses, e.g. --response yes,waitlist")
+		return exportattendeescmd
+	}
+)
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/app"
+	"github.com/hairizuanbinnoorazman/techmeetup/eventstore"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditCmd = func() *cobra.Command {
+		var configFile string
+		var event string
+		var from string
+		var to string
+		var outputJSON bool
+		auditcmd := &cobra.Command{
+			Use:   "audit",
+			Short: "Show the changes that were applied to Meetup, Streamyard and Google Calendar",
+			Long: `
+This utility reads the audit log defined by audit_log in the config file. Every change that was
+applied to the platforms by a sync is recorded in it, along with the values before and after the
+change and whether it succeeded. Dates for --from and --to are in the 2006-01-02 format and are
+in the time zone in config.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				config, err := app.NewBasicConfigStore(configFile).Get()
+				if err != nil {
+					logrus.Errorf("Unable to read config file. Err: %v", err)
+					os.Exit(1)
+				}
+				if config.AuditLog == "" {
+					logrus.Errorf("Audit log is not set in config")
+					os.Exit(1)
+				}
+				loc, err := config.Location()
+				if err != nil {
+					logrus.Error(err)
+					os.Exit(1)
+				}
+				q := eventstore.AuditQuery{Event: event}
+				if from != "" {
+					q.From, err = time.ParseInLocation("2006-01-02", from, loc)
+					if err != nil {
+						logrus.Errorf("Unable to parse --from. Err: %v", err)
+						os.Exit(1)
+					}
+				}
+				if to != "" {
+					toDate, err := time.ParseInLocation("2006-01-02", to, loc)
+					if err != nil {
+						logrus.Errorf("Unable to parse --to. Err: %v", err)
+						os.Exit(1)
+					}
+					// The whole of the last day is included
+					q.To = toDate.AddDate(0, 0, 1).Add(-time.Nanosecond)
+				}
+				entries, err := eventstore.NewAuditLog(config.AuditLog).Query(q)
+				if err != nil {
+					logrus.Errorf("Unable to query audit log. Err: %v", err)
+					os.Exit(1)
+				}
+				if outputJSON {
+					raw, _ := json.MarshalIndent(entries, "", "  ")
+					fmt.Println(string(raw))
+					return
+				}
+				for _, entry := range entries {
+					fmt.Printf("%v %v (%v) %v: %v [%v]", entry.Time.In(loc).Format(time.RFC3339), entry.Title, entry.EventID, entry.Platform, entry.Operation, entry.Result)
+					if entry.Error != "" {
+						fmt.Printf(" %v", entry.Error)
+					}
+					fmt.Print("\n")
+					for _, d := range entry.Diffs {
+						fmt.Printf("    %v: %q => %q\n", d.Field, d.Before, d.After)
+					}
+				}
+			},
+		}
+		auditcmd.Flags().StringVar(&configFile, "config", "config.yaml", "Configuration file. Please utilize the fetcher to ensure the right format of config is used")
+		auditcmd.Flags().StringVar(&event, "event", "", "ID or title of the event. All events are shown if empty")
+		auditcmd.Flags().StringVar(&from, "from", "", "Only show changes from this date onwards, e.g. 2021-01-31")
+		auditcmd.Flags().StringVar(&to, "to", "", "Only show changes up till this date, e.g. 2021-01-31")
+		auditcmd.Flags().BoolVar(&outputJSON, "json", false, "Print the entries as json")
+		return auditcmd
+	}
+)
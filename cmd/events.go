@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/app"
+	"github.com/hairizuanbinnoorazman/techmeetup/eventstore"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	eventsCmd = func() *cobra.Command {
+		eventscmd := &cobra.Command{
+			Use:   "events",
+			Short: "Utilities to manage the events held in the eventstore",
+			Long:  ``,
+			Run: func(cmd *cobra.Command, args []string) {
+				cmd.Help()
+			},
+		}
+		eventscmd.AddCommand(migrateEventsCmd())
+		eventscmd.AddCommand(validateEventsCmd())
+		eventscmd.AddCommand(importEventsCmd())
+		eventscmd.AddCommand(icalEventsCmd())
+		return eventscmd
+	}
+
+	migrateEventsCmd = func() *cobra.Command {
+		var configFile string
+		var sourceType string
+		migrateeventscmd := &cobra.Command{
+			Use:   "migrate [Source eventstore path]",
+			Short: "Copy all events from a source eventstore into the eventstore defined in config",
+			Long: `
+This utility reads all events from the source eventstore and writes them into the eventstore
+configured in the config file. It can be used to move from the yaml file eventstore into the
+bolt eventstore. For the sheets eventstore, the path is the ID of the spreadsheet. Events with
+the same ID in the destination eventstore would be overwritten.`,
+			Args: cobra.ExactArgs(1),
+			Run: func(cmd *cobra.Command, args []string) {
+				config, err := app.NewBasicConfigStore(configFile).Get()
+				if err != nil {
+					logrus.Errorf("Unable to read config file. Err: %v", err)
+					os.Exit(1)
+				}
+				runner := app.NewApp(app.NewBasicConfigStore(configFile), logrus.New())
+				runner.RerunAuth()
+				source, err := runner.NewStore(sourceType, args[0])
+				if err != nil {
+					logrus.Errorf("Unable to setup source eventstore. Err: %v", err)
+					os.Exit(1)
+				}
+				destination, err := runner.NewStore(config.EventStoreType, config.EventStoreFile)
+				if err != nil {
+					logrus.Errorf("Unable to setup destination eventstore. Err: %v", err)
+					os.Exit(1)
+				}
+				events, err := source.List()
+				if err != nil {
+					logrus.Errorf("Unable to list events from source eventstore. Err: %v", err)
+					os.Exit(1)
+				}
+				for _, e := range events {
+					err = destination.Put(e)
+					if err != nil {
+						logrus.Errorf("Unable to save event into destination eventstore. Event: %v Err: %v", e.Title, err)
+						os.Exit(1)
+					}
+				}
+				logrus.Infof("Migrated %v events", len(events))
+			},
+		}
+		migrateeventscmd.Flags().StringVar(&configFile, "config", "config.yaml", "Configuration file. Please utilize the fetcher to ensure the right format of config is used")
+		migrateeventscmd.Flags().StringVar(&sourceType, "source-type", "yaml", "Type of the source eventstore. One of yaml, bolt, dir or sheets")
+		return migrateeventscmd
+	}
+
+	validateEventsCmd = func() *cobra.Command {
+		var configFile string
+		validateeventscmd := &cobra.Command{
+			Use:   "validate",
+			Short: "Check all events in the eventstore and report every issue found",
+			Long: `
+This utility checks all events in the eventstore defined in the config file. Required fields,
+date formats, agenda items, emails, duplicated titles and IDs as well as image paths are
+checked. For the yaml eventstore, issues are reported with the line and column in the file.
+Invalid events are not synced to any of the platforms.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				config, err := app.NewBasicConfigStore(configFile).Get()
+				if err != nil {
+					logrus.Errorf("Unable to read config file. Err: %v", err)
+					os.Exit(1)
+				}
+				speakers, err := config.Speakers()
+				if err != nil {
+					logrus.Errorf("Unable to load speakers. Err: %v", err)
+					os.Exit(1)
+				}
+				var issues []eventstore.ValidationIssue
+				if config.EventStoreType == "" || config.EventStoreType == "yaml" {
+					issues, err = eventstore.ValidateFile(config.EventStoreFile, config.Venues, speakers)
+					if err != nil {
+						logrus.Errorf("Unable to validate eventstore. Err: %v", err)
+						os.Exit(1)
+					}
+				} else {
+					runner := app.NewApp(app.NewBasicConfigStore(configFile), logrus.New())
+					runner.RerunAuth()
+					store, err := runner.NewStore(config.EventStoreType, config.EventStoreFile)
+					if err != nil {
+						logrus.Errorf("Unable to setup eventstore. Err: %v", err)
+						os.Exit(1)
+					}
+					events, err := store.List()
+					if err != nil {
+						logrus.Errorf("Unable to list events from eventstore. Err: %v", err)
+						os.Exit(1)
+					}
+					issues = append(eventstore.ValidateEvents(events), eventstore.ValidateVenues(events, config.Venues)...)
+					issues = append(issues, eventstore.ValidateSpeakers(events, speakers)...)
+				}
+				for _, issue := range issues {
+					fmt.Printf("%v:%v\n", config.EventStoreFile, issue.Error())
+				}
+				if len(issues) > 0 {
+					logrus.Errorf("Found %v issues in the eventstore", len(issues))
+					os.Exit(1)
+				}
+				logrus.Info("No issues found in the eventstore")
+			},
+		}
+		validateeventscmd.Flags().StringVar(&configFile, "config", "config.yaml", "Configuration file. Please utilize the fetcher to ensure the right format of config is used")
+		return validateeventscmd
+	}
+
+	importEventsCmd = func() *cobra.Command {
+		var configFile string
+		var output string
+		var force bool
+		importeventscmd := &cobra.Command{
+			Use:   "import",
+			Short: "Import the existing events on Meetup and Streamyard into a yaml eventstore",
+			Long: `
+This utility pulls the upcoming and past events from meetup.com as well as the broadcasts from
+Streamyard and writes them into a yaml eventstore. Meetup events and broadcasts with the same
+title and start time are joined into a single event with both IDs and the youtube link filled in.
+Imported events are not tracked; review them and set track_event before running a sync.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				config, err := app.NewBasicConfigStore(configFile).Get()
+				if err != nil {
+					logrus.Errorf("Unable to read config file. Err: %v", err)
+					os.Exit(1)
+				}
+				if output == "" {
+					if config.EventStoreType != "" && config.EventStoreType != "yaml" {
+						logrus.Errorf("The eventstore in config is not a yaml eventstore. Please set --output and migrate the events with the migrate command")
+						os.Exit(1)
+					}
+					output = config.EventStoreFile
+				}
+				if _, err := os.Stat(output); err == nil && !force {
+					logrus.Errorf("%v already exists. Use --force to overwrite it", output)
+					os.Exit(1)
+				}
+				loc, err := config.Location()
+				if err != nil {
+					logrus.Error(err)
+					os.Exit(1)
+				}
+				runner := app.NewApp(app.NewBasicConfigStore(configFile), logrus.New())
+				runner.RerunAuth()
+				meetupClient := runner.MeetupClient()
+				streamyardClient := runner.StreamyardClient()
+
+				ctx := context.Background()
+				upcoming, err := meetupClient.ListUpcomingEvents(ctx)
+				if err != nil {
+					logrus.Errorf("Unable to list upcoming events from meetup. Err: %v", err)
+					os.Exit(1)
+				}
+				past, err := meetupClient.ListPastEvents(ctx)
+				if err != nil {
+					logrus.Errorf("Unable to list past events from meetup. Err: %v", err)
+					os.Exit(1)
+				}
+				streams, err := streamyardClient.ListStreams(ctx)
+				if err != nil {
+					logrus.Errorf("Unable to list streams from streamyard. Err: %v", err)
+					os.Exit(1)
+				}
+				completed, err := streamyardClient.ListCompletedStreams(ctx)
+				if err != nil {
+					logrus.Errorf("Unable to list completed streams from streamyard. Err: %v", err)
+					os.Exit(1)
+				}
+
+				events := eventstore.ImportEvents(append(past, upcoming...), append(completed, streams...), loc)
+				err = eventstore.WriteYAMLFile(output, events)
+				if err != nil {
+					logrus.Errorf("Unable to write eventstore. Err: %v", err)
+					os.Exit(1)
+				}
+				logrus.Infof("Imported %v events into %v", len(events), output)
+			},
+		}
+		importeventscmd.Flags().StringVar(&configFile, "config", "config.yaml", "Configuration file. Please utilize the fetcher to ensure the right format of config is used")
+		importeventscmd.Flags().StringVar(&output, "output", "", "Path of the yaml eventstore to write. Defaults to the eventstore in config")
+		importeventscmd.Flags().BoolVar(&force, "force", false, "Overwrite the output file if it already exists")
+		return importeventscmd
+	}
+
+	icalEventsCmd = func() *cobra.Command {
+		var configFile string
+		var output string
+		icaleventscmd := &cobra.Command{
+			Use:   "ical",
+			Short: "Export the public events in the eventstore as an iCalendar feed",
+			Long: `
+This utility writes the same iCalendar feed that is served by the server on /calendar.ics.
+Only public events that are tracked are included. Cancelled events remain in the feed and are
+marked as cancelled so that calendars that subscribed to the feed remove them.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				s := eventStoreHelper(configFile)
+				feed, err := s.ICalendar(time.Now())
+				if err != nil {
+					logrus.Errorf("Unable to generate calendar feed. Err: %v", err)
+					os.Exit(1)
+				}
+				if output == "" {
+					fmt.Print(feed)
+					return
+				}
+				err = ioutil.WriteFile(output, []byte(feed), 0644)
+				if err != nil {
+					logrus.Errorf("Unable to write calendar feed. Err: %v", err)
+					os.Exit(1)
+				}
+				logrus.Infof("Calendar feed written to %v", output)
+			},
+		}
+		icaleventscmd.Flags().StringVar(&configFile, "config", "config.yaml", "Configuration file. Please utilize the fetcher to ensure the right format of config is used")
+		icaleventscmd.Flags().StringVar(&output, "output", "", "Path of the .ics file to write. Prints the feed if empty")
+		return icaleventscmd
+	}
+)
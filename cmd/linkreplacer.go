@@ -61,7 +61,7 @@ Further improvements can be added to this tool in the future`,
 					logrus.Errorf("Unable to create slide service. We will not proceed. Err: %v", err)
 					os.Exit(1)
 				}
-				gslides := tslides.NewGoogleSlides(logrus.StandardLogger(), slideService)
+				gslides := tslides.NewGoogleSlides(logrus.StandardLogger(), slideService, nil)
 				items, err := gslides.GetAllText(context.Background(), presentationSlideID)
 				if err != nil {
 					logrus.Errorf("Unable to fetch text data from slides. Err: %v", err)
@@ -131,7 +131,7 @@ Further improvements can be added to this tool in the future`,
 					logrus.Errorf("Unable to create slides service. We will not proceed. Err: %v", err)
 					os.Exit(1)
 				}
-				gslides := tslides.NewGoogleSlides(logrus.StandardLogger(), slideService)
+				gslides := tslides.NewGoogleSlides(logrus.StandardLogger(), slideService, nil)
 				bitlyClient := urlshortener.NewBitly(logrus.New(), http.DefaultClient, accessToken)
 				for idx, val := range cleanedLinks {
 					if val.ReplaceText != "" {
@@ -16,6 +16,11 @@ var (
 		}
 		cmd.AddCommand(serverCmd())
 		cmd.AddCommand(linkreplacerCmd())
+		cmd.AddCommand(planCmd())
+		cmd.AddCommand(applyCmd())
+		cmd.AddCommand(eventsCmd())
+		cmd.AddCommand(auditCmd())
+		cmd.AddCommand(attendeesCmd())
 		cmd.AddCommand(versionCmd())
 		return cmd
 	}
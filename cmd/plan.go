@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/app"
+	"github.com/hairizuanbinnoorazman/techmeetup/eventstore"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	planCmd = func() *cobra.Command {
+		var configFile string
+		plancmd := &cobra.Command{
+			Use:   "plan",
+			Short: "Show the changes that would be made to Meetup, Streamyard and Google Calendar",
+			Long: `
+This utility compares the events in the eventstore against what is currently on the various
+platforms and prints out the changes that would be made. Nothing is changed on any of the platforms.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				s := eventStoreHelper(configFile)
+				p, err := s.Plan(context.Background())
+				if err != nil {
+					logrus.Errorf("Unable to compute plan. Err: %v", err)
+					os.Exit(1)
+				}
+				fmt.Print(p)
+			},
+		}
+		plancmd.Flags().StringVar(&configFile, "config", "config.yaml", "Configuration file. Please utilize the fetcher to ensure the right format of config is used")
+		return plancmd
+	}
+
+	applyCmd = func() *cobra.Command {
+		var configFile string
+		var autoApprove bool
+		applycmd := &cobra.Command{
+			Use:   "apply",
+			Short: "Apply the changes to Meetup, Streamyard and Google Calendar",
+			Long: `
+This utility computes the plan (same as the plan command), prints it and once approved, applies
+the changes to the various platforms. Platform IDs are written back to the eventstore. Changes
+that differ from the approved plan, e.g. as the eventstore was edited while waiting for the
+approval, are not applied and are reported as errors.`,
+			Run: func(cmd *cobra.Command, args []string) {
+				// Answers are read from one reader so that answers piped in together are not lost
+				// to the buffer of an earlier prompt
+				stdin := bufio.NewReader(os.Stdin)
+				opts := []eventstore.Option{}
+				if !autoApprove {
+					opts = append(opts, eventstore.WithAdoptConfirm(confirmAdopt(stdin)))
+				}
+				s := eventStoreHelper(configFile, opts...)
+				p, err := s.Plan(context.Background())
+				if err != nil {
+					logrus.Errorf("Unable to compute plan. Err: %v", err)
+					os.Exit(1)
+				}
+				fmt.Print(p)
+				if !p.HasChanges() {
+					fmt.Println("No changes. Platforms are up to date with the eventstore")
+					return
+				}
+				if !autoApprove && !confirm(stdin, "Do you want to apply these changes? Only 'yes' will be accepted: ") {
+					fmt.Println("Apply cancelled")
+					return
+				}
+				result, err := s.Apply(context.Background(), &p)
+				if err != nil {
+					logrus.Errorf("Unable to apply plan. Err: %v", err)
+					os.Exit(1)
+				}
+				fmt.Print(result)
+			},
+		}
+		applycmd.Flags().StringVar(&configFile, "config", "config.yaml", "Configuration file. Please utilize the fetcher to ensure the right format of config is used")
+		applycmd.Flags().BoolVar(&autoApprove, "auto-approve", false, "Skip the interactive approval of the plan and of adopting existing events before applying")
+		return applycmd
+	}
+)
+
+func eventStoreHelper(configFile string, opts ...eventstore.Option) eventstore.EventStore {
+	runner := app.NewApp(app.NewBasicConfigStore(configFile), logrus.New())
+	runner.RerunAuth()
+	s, err := runner.NewEventStore(opts...)
+	if err != nil {
+		logrus.Errorf("Unable to setup eventstore. Err: %v", err)
+		os.Exit(1)
+	}
+	return s
+}
+
+func confirm(stdin *bufio.Reader, question string) bool {
+	fmt.Print(question)
+	answer, _ := stdin.ReadString('\n')
+	return strings.TrimSpace(answer) == "yes"
+}
+
+// confirmAdopt asks before existing events on platforms are adopted. Events are synced
+// concurrently, so the questions are asked one at a time
+func confirmAdopt(stdin *bufio.Reader) func(c eventstore.Change) bool {
+	var mu sync.Mutex
+	return func(c eventstore.Change) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Printf("Found an existing %v event for %v (%v)\n", c.Platform, c.Title, c.EventID)
+		for _, d := range c.Diffs {
+			fmt.Printf("  %v: %v\n", d.Field, d.After)
+		}
+		return confirm(stdin, "Do you want to adopt it instead of creating a new one? Only 'yes' will be accepted: ")
+	}
+}
@@ -21,6 +21,7 @@ var (
 			Long:  ``,
 			Run: func(cmd *cobra.Command, args []string) {
 				notifyConfigChange := make(chan bool)
+				notifyEventChange := make(chan string)
 				notifyInterrupts := make(chan os.Signal, 1)
 				signal.Notify(notifyInterrupts, syscall.SIGINT, syscall.SIGTERM)
 
@@ -30,12 +31,18 @@ var (
 				}
 				defer watcher.Close()
 				watcher.Add(configFile)
-				go app.ConfigFileWatcher(watcher, notifyConfigChange)
 
 				configStore := app.NewBasicConfigStore(configFile)
+				eventDir := ""
+				if config, err := configStore.Get(); err == nil && config.EventStoreType == "dir" {
+					eventDir = config.EventStoreFile
+					watcher.Add(eventDir)
+				}
+				go app.ConfigFileWatcher(watcher, notifyConfigChange, eventDir, notifyEventChange)
+
 				runner := app.NewApp(configStore, logrus.New())
 				runner.Initialize()
-				runner.Run(notifyConfigChange, notifyInterrupts)
+				runner.Run(notifyConfigChange, notifyEventChange, notifyInterrupts)
 			},
 		}
 		cmd.Flags().StringVar(&configFile, "config", "config.yaml", "Configuration file. Please utilize the fetcher to ensure the right format of config is used")
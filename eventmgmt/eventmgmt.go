@@ -9,6 +9,16 @@ import (
 	"time"
 )
 
+// Venue is a physical location where in person events are held
+type Venue struct {
+	ID      string
+	Name    string
+	Address string
+	City    string
+	// Country is the ISO 3166 two letter country code, e.g. sg
+	Country string
+}
+
 type EventMgmt interface {
 	ListUpcomingEvents(ctx context.Context) ([]Event, error)
 	ListPastEvents(ctx context.Context) ([]Event, error)
@@ -24,6 +34,16 @@ type Event struct {
 	IsWebinar   bool
 	IsPublic    bool
 	WebinarLink string
+	// Status of the event on the platform, e.g. upcoming, cancelled
+	Status string
+	// VenueID is the venue of in person events. Events without a venue are online events
+	VenueID string
+	// HowToFindUs defaults to the webinar link if not set
+	HowToFindUs string
+	// RSVPLimit is the maximum number of attendees. No limit if 0
+	RSVPLimit int
+	// RSVPCount is the number of members that are going. It is only read from the platform
+	RSVPCount int
 	// Meetup organizer
 	Organizers []string
 	// Time in minutes
@@ -31,8 +51,8 @@ type Event struct {
 	Duration int
 }
 
-func NewEvent(name, description, startTime string) (Event, error) {
-	loc, _ := time.LoadLocation("Asia/Singapore")
+// NewEvent creates a webinar event with startTime being the local time in the loc time zone
+func NewEvent(name, description, startTime string, loc *time.Location) (Event, error) {
 	zz, err := time.ParseInLocation("2006-01-02T15:04:05", startTime, loc)
 	if err != nil {
 		return Event{}, err
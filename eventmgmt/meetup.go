@@ -36,9 +36,20 @@ type MeetupEventResp struct {
 	Status        string            `json:"status"`
 	Time          int64             `json:"time"`
 	HowToFindUs   string            `json:"how_to_find_us"`
+	RSVPLimit     int               `json:"rsvp_limit"`
+	YesRSVPCount  int               `json:"yes_rsvp_count"`
+	Venue         MeetupVenue       `json:"venue"`
 	EventHosts    []MeetupEventHost `json:"event_hosts"`
 }
 
+type MeetupVenue struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Address1 string `json:"address_1"`
+	City     string `json:"city"`
+	Country  string `json:"country"`
+}
+
 type MeetupEventHost struct {
 	ID       int    `json:"id"`
 	Intro    string `json:"intro"`
@@ -46,6 +57,31 @@ type MeetupEventHost struct {
 	Name     string `json:"name"`
 }
 
+type MeetupRSVPResp struct {
+	Created  int64              `json:"created"`
+	Updated  int64              `json:"updated"`
+	Response string             `json:"response"`
+	Guests   int                `json:"guests"`
+	Member   MeetupRSVPMember   `json:"member"`
+	Answers  []MeetupRSVPAnswer `json:"answers"`
+}
+
+type MeetupRSVPMember struct {
+	ID           int                    `json:"id"`
+	Name         string                 `json:"name"`
+	EventContext MeetupRSVPEventContext `json:"event_context"`
+}
+
+type MeetupRSVPEventContext struct {
+	Host bool `json:"host"`
+}
+
+type MeetupRSVPAnswer struct {
+	QuestionID int    `json:"question_id"`
+	Question   string `json:"question"`
+	Answer     string `json:"answer"`
+}
+
 type MeetupFeaturedPhoto struct {
 	BaseURL     string `json:"base_url"`
 	HighresLink string `json:"highres_link"`
@@ -65,20 +101,18 @@ func NewMeetup(logger logger.Logger, client *http.Client, meetupGroup, accessTok
 	}
 }
 
+// EventLink is the public page of the meetup event where members RSVP
+func (m *Meetup) EventLink(id string) string {
+	return fmt.Sprintf("https://www.meetup.com/%v/events/%v/", m.meetupGroup, id)
+}
+
 // ListUpcomingEvents list out all upcoming events on meetup page
 func (m *Meetup) ListUpcomingEvents(ctx context.Context) ([]Event, error) {
-	url := fmt.Sprintf("https://api.meetup.com/%v/events", m.meetupGroup)
-	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	resp, err := m.client.Do(req)
-	if err != nil {
-		return []Event{}, fmt.Errorf("Unable to fetch event. Err: %v", err)
-	}
-	raw, _ := ioutil.ReadAll(resp.Body)
-	m.logger.Info(string(raw))
-	return []Event{}, nil
+	url := fmt.Sprintf("https://api.meetup.com/%v/events?fields=event_hosts", m.meetupGroup)
+	return m.listEvents(ctx, url)
 }
 
-// ListPastEvents list out all upcoming events on meetup page
+// ListPastEvents list out all past events on meetup page
 func (m *Meetup) ListPastEvents(ctx context.Context) ([]Event, error) {
 	rawURL := fmt.Sprintf("https://api.meetup.com/%v/events", m.meetupGroup)
 	finalURL, err := url.ParseRequestURI(rawURL)
@@ -88,16 +122,60 @@ func (m *Meetup) ListPastEvents(ctx context.Context) ([]Event, error) {
 	queries := finalURL.Query()
 	queries.Add("has_ended", "true")
 	queries.Add("status", "past")
+	queries.Add("fields", "event_hosts")
 	finalURL.RawQuery = queries.Encode()
-	m.logger.Info(finalURL.String())
-	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, finalURL.String(), nil)
-	resp, err := m.client.Do(req)
-	if err != nil {
-		return []Event{}, fmt.Errorf("Unable to fetch event. Err: %v", err)
+	return m.listEvents(ctx, finalURL.String())
+}
+
+// listEvents goes through all pages of events, starting from the url. Meetup returns the link to
+// the next page in the Link header
+func (m *Meetup) listEvents(ctx context.Context, url string) ([]Event, error) {
+	events := []Event{}
+	for url != "" {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", m.accessToken))
+		resp, err := m.client.Do(req)
+		if err != nil {
+			return []Event{}, fmt.Errorf("Unable to fetch event. Err: %v", err)
+		}
+		raw, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return []Event{}, fmt.Errorf("Unable to fetch events. Response is not ok.\nStatusCode: %v\nBody: %v", resp.StatusCode, string(raw))
+		}
+		page, err := parseMeetupEvents(raw)
+		if err != nil {
+			return []Event{}, err
+		}
+		events = append(events, page...)
+		url = nextPageLink(resp.Header.Get("Link"))
 	}
-	raw, _ := ioutil.ReadAll(resp.Body)
-	m.logger.Info(string(raw))
-	return []Event{}, nil
+	return events, nil
+}
+
+// nextPageLink reads the link to the next page from a Link header, e.g.
+// <https://api.meetup.com/group/events?page=20&offset=1>; rel="next". Empty if it is the last page
+func nextPageLink(header string) string {
+	for header != "" {
+		start := strings.Index(header, "<")
+		end := strings.Index(header, ">")
+		if start < 0 || end < start {
+			return ""
+		}
+		link := header[start+1 : end]
+		header = header[end+1:]
+		// Parameters of the link run up to the next link. Links may contain commas themselves
+		params := header
+		if next := strings.Index(header, "<"); next >= 0 {
+			params = header[:next]
+		}
+		for _, param := range strings.Split(params, ";") {
+			if strings.TrimSpace(strings.TrimRight(strings.TrimSpace(param), ",")) == `rel="next"` {
+				return link
+			}
+		}
+	}
+	return ""
 }
 
 func (m *Meetup) GetEvent(ctx context.Context, id string) (Event, error) {
@@ -118,22 +196,50 @@ func (m *Meetup) GetEvent(ctx context.Context, id string) (Event, error) {
 	if err != nil {
 		return Event{}, fmt.Errorf("Error in parsing response from meetup.com. Err: %v", err)
 	}
-	unixStartTime := meetupResp.Time / 1000
+	return meetupResp.toEvent(), nil
+}
+
+func parseMeetupEvents(raw []byte) ([]Event, error) {
+	var meetupResp []MeetupEventResp
+	err := json.Unmarshal(raw, &meetupResp)
+	if err != nil {
+		return []Event{}, fmt.Errorf("Error in parsing response from meetup.com. Err: %v", err)
+	}
+	events := []Event{}
+	for _, r := range meetupResp {
+		events = append(events, r.toEvent())
+	}
+	return events, nil
+}
+
+// toEvent converts the event returned by meetup.com
+func (r MeetupEventResp) toEvent() Event {
+	unixStartTime := r.Time / 1000
 	startTime := time.Unix(unixStartTime, 0)
 	organizers := []string{}
-	for _, org := range meetupResp.EventHosts {
+	for _, org := range r.EventHosts {
 		organizers = append(organizers, strconv.Itoa(org.ID))
 	}
+	venueID := ""
+	if r.Venue.ID != 0 {
+		venueID = strconv.Itoa(r.Venue.ID)
+	}
 	return Event{
-		ID:          meetupResp.ID,
+		ID:          r.ID,
 		StartTime:   startTime,
-		Name:        meetupResp.Name,
-		Description: meetupResp.Description,
-		IsWebinar:   meetupResp.IsOnlineEvent,
-		WebinarLink: meetupResp.HowToFindUs,
+		Name:        r.Name,
+		Description: r.Description,
+		IsWebinar:   r.IsOnlineEvent,
+		IsPublic:    r.Status != "draft",
+		WebinarLink: r.HowToFindUs,
+		Status:      r.Status,
+		VenueID:     venueID,
+		HowToFindUs: r.HowToFindUs,
+		RSVPLimit:   r.RSVPLimit,
+		RSVPCount:   r.YesRSVPCount,
 		Organizers:  organizers,
-		Duration:    int(meetupResp.Duration / (1000 * 60)),
-	}, nil
+		Duration:    int(r.Duration / (1000 * 60)),
+	}
 }
 
 func (m *Meetup) UploadPhoto(ctx context.Context, eventID, photoFilePath string) (string, error) {
@@ -177,7 +283,7 @@ func (m *Meetup) UploadPhoto(ctx context.Context, eventID, photoFilePath string)
 }
 
 func (m *Meetup) CreateDraftEvent(ctx context.Context, e Event) (Event, error) {
-	if e.Description == "" || e.Name == "" || len(e.Organizers) == 0 || e.StartTime.IsZero() || e.WebinarLink == "" {
+	if e.Description == "" || e.Name == "" || len(e.Organizers) == 0 || e.StartTime.IsZero() || (e.WebinarLink == "" && e.VenueID == "") {
 		return e, fmt.Errorf("Missing items in event. Event: %v", e)
 	}
 
@@ -185,7 +291,10 @@ func (m *Meetup) CreateDraftEvent(ctx context.Context, e Event) (Event, error) {
 	data := url.Values{}
 
 	// Modify description
-	desc := AppendYoutubeLinktoDesc(e.Description, e.WebinarLink)
+	desc := e.Description
+	if e.WebinarLink != "" {
+		desc = AppendYoutubeLinktoDesc(e.Description, e.WebinarLink)
+	}
 	// desc = ConvertDescriptionToMeetupHTML(desc)
 
 	data.Set("announce", "false")
@@ -198,9 +307,8 @@ func (m *Meetup) CreateDraftEvent(ctx context.Context, e Event) (Event, error) {
 		data.Set("publish_status", "draft")
 	}
 	data.Set("time", strconv.Itoa(int(e.StartTime.Unix()*1000)))
-	data.Set("venue_id", "online")
+	setLocation(data, e)
 	data.Set("description", desc)
-	data.Set("how_to_find_us", e.WebinarLink)
 
 	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, initialURl, strings.NewReader(data.Encode()))
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
@@ -220,6 +328,23 @@ func (m *Meetup) CreateDraftEvent(ctx context.Context, e Event) (Event, error) {
 	return e, nil
 }
 
+// setLocation sets the venue of the event. Events without a venue are online events
+func setLocation(data url.Values, e Event) {
+	if e.VenueID == "" {
+		data.Set("venue_id", "online")
+	} else {
+		data.Set("venue_id", e.VenueID)
+	}
+	if e.HowToFindUs != "" {
+		data.Set("how_to_find_us", e.HowToFindUs)
+	} else {
+		data.Set("how_to_find_us", e.WebinarLink)
+	}
+	if e.RSVPLimit > 0 {
+		data.Set("rsvp_limit", strconv.Itoa(e.RSVPLimit))
+	}
+}
+
 func WithFeaturedPhoto(photoID string) func(url.Values) {
 	return func(d url.Values) {
 		d.Add("featured_photo_id", photoID)
@@ -227,14 +352,17 @@ func WithFeaturedPhoto(photoID string) func(url.Values) {
 }
 
 func (m *Meetup) UpdateEvent(ctx context.Context, e Event, f ...func(url.Values)) (Event, error) {
-	if e.ID == "" || e.Name == "" || e.Description == "" || len(e.Organizers) == 0 || e.StartTime.IsZero() || e.WebinarLink == "" {
+	if e.ID == "" || e.Name == "" || e.Description == "" || len(e.Organizers) == 0 || e.StartTime.IsZero() || (e.WebinarLink == "" && e.VenueID == "") {
 		return Event{}, fmt.Errorf("Missing event details. Event: %+v", e)
 	}
 	initialURl := fmt.Sprintf("https://api.meetup.com/%v/events/%v", m.meetupGroup, e.ID)
 	data := url.Values{}
 
 	// Modify description
-	desc := AppendYoutubeLinktoDesc(e.Description, e.WebinarLink)
+	desc := e.Description
+	if e.WebinarLink != "" {
+		desc = AppendYoutubeLinktoDesc(e.Description, e.WebinarLink)
+	}
 	// desc = ConvertDescriptionToMeetupHTML(desc)
 
 	data.Set("announce", "false")
@@ -247,9 +375,8 @@ func (m *Meetup) UpdateEvent(ctx context.Context, e Event, f ...func(url.Values)
 		data.Set("publish_status", "draft")
 	}
 	data.Set("time", strconv.Itoa(int(e.StartTime.Unix()*1000)))
-	data.Set("venue_id", "online")
+	setLocation(data, e)
 	data.Set("description", desc)
-	data.Set("how_to_find_us", e.WebinarLink)
 
 	for _, z := range f {
 		z(data)
@@ -272,3 +399,148 @@ func (m *Meetup) UpdateEvent(ctx context.Context, e Event, f ...func(url.Values)
 	e.ID = meetupResp.ID
 	return e, nil
 }
+
+// CancelEvent cancels the event on meetup. The event is kept on the meetup page with the
+// cancelled status rather than being removed
+func (m *Meetup) CancelEvent(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("Event ID is missing. Please provide it")
+	}
+	rawURL := fmt.Sprintf("https://api.meetup.com/%v/events/%v", m.meetupGroup, id)
+	finalURL, err := url.ParseRequestURI(rawURL)
+	if err != nil {
+		return err
+	}
+	queries := finalURL.Query()
+	queries.Add("remove", "false")
+	finalURL.RawQuery = queries.Encode()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodDelete, finalURL.String(), nil)
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", m.accessToken))
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Unable to cancel event. Err: %v", err)
+	}
+	raw, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("Unable to cancel event. Response is not ok.\nStatusCode: %v\nBody: %v", resp.StatusCode, string(raw))
+	}
+	return nil
+}
+
+// ListVenues lists the venues that were used by the meetup group
+func (m *Meetup) ListVenues(ctx context.Context) ([]Venue, error) {
+	url := fmt.Sprintf("https://api.meetup.com/%v/venues", m.meetupGroup)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", m.accessToken))
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return []Venue{}, fmt.Errorf("Unable to fetch venues. Err: %v", err)
+	}
+	raw, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return []Venue{}, fmt.Errorf("Unable to fetch venues. Response is not ok.\nStatusCode: %v\nBody: %v", resp.StatusCode, string(raw))
+	}
+	var meetupResp []MeetupVenue
+	err = json.Unmarshal(raw, &meetupResp)
+	if err != nil {
+		return []Venue{}, fmt.Errorf("Error in parsing response from meetup.com. Err: %v", err)
+	}
+	venues := []Venue{}
+	for _, v := range meetupResp {
+		venues = append(venues, Venue{
+			ID:      strconv.Itoa(v.ID),
+			Name:    v.Name,
+			Address: v.Address1,
+			City:    v.City,
+			Country: v.Country,
+		})
+	}
+	return venues, nil
+}
+
+// CreateVenue creates a venue for the meetup group. The ID of the created venue is returned
+func (m *Meetup) CreateVenue(ctx context.Context, v Venue) (Venue, error) {
+	if v.Name == "" || v.Address == "" || v.City == "" || v.Country == "" {
+		return v, fmt.Errorf("Missing venue details. Venue: %+v", v)
+	}
+	initialURL := fmt.Sprintf("https://api.meetup.com/%v/venues", m.meetupGroup)
+	data := url.Values{}
+	data.Set("name", v.Name)
+	data.Set("address_1", v.Address)
+	data.Set("city", v.City)
+	data.Set("country", v.Country)
+	data.Set("visibility", "public")
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, initialURL, strings.NewReader(data.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", m.accessToken))
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return v, fmt.Errorf("Unable to create venue. Err: %v", err)
+	}
+	raw, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return v, fmt.Errorf("Unable to create venue. Response is not ok.\nStatusCode: %v\nBody: %v", resp.StatusCode, string(raw))
+	}
+	var meetupResp MeetupVenue
+	err = json.Unmarshal(raw, &meetupResp)
+	if err != nil {
+		return v, fmt.Errorf("Error in parsing response from meetup.com. Err: %v", err)
+	}
+	v.ID = strconv.Itoa(meetupResp.ID)
+	return v, nil
+}
+
+// ListRSVPs lists the RSVPs of the event along with the answers to the RSVP questions. RSVPs
+// of all responses are returned if no responses are provided
+func (m *Meetup) ListRSVPs(ctx context.Context, eventID string, responses ...string) ([]RSVP, error) {
+	if len(responses) == 0 {
+		responses = []string{RSVPYes, RSVPNo, RSVPWaitlist}
+	}
+	rawURL := fmt.Sprintf("https://api.meetup.com/%v/events/%v/rsvps", m.meetupGroup, eventID)
+	finalURL, err := url.ParseRequestURI(rawURL)
+	if err != nil {
+		return []RSVP{}, err
+	}
+	queries := finalURL.Query()
+	queries.Add("response", strings.Join(responses, ","))
+	queries.Add("fields", "answers")
+	finalURL.RawQuery = queries.Encode()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, finalURL.String(), nil)
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", m.accessToken))
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return []RSVP{}, fmt.Errorf("Unable to fetch rsvps. Err: %v", err)
+	}
+	raw, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return []RSVP{}, fmt.Errorf("Unable to fetch rsvps. Response is not ok.\nStatusCode: %v\nBody: %v", resp.StatusCode, string(raw))
+	}
+	return parseMeetupRSVPs(raw)
+}
+
+func parseMeetupRSVPs(raw []byte) ([]RSVP, error) {
+	var meetupResp []MeetupRSVPResp
+	err := json.Unmarshal(raw, &meetupResp)
+	if err != nil {
+		return []RSVP{}, fmt.Errorf("Error in parsing response from meetup.com. Err: %v", err)
+	}
+	rsvps := []RSVP{}
+	for _, r := range meetupResp {
+		answers := []RSVPAnswer{}
+		for _, a := range r.Answers {
+			answers = append(answers, RSVPAnswer{Question: a.Question, Answer: a.Answer})
+		}
+		rsvps = append(rsvps, RSVP{
+			MemberID: strconv.Itoa(r.Member.ID),
+			Name:     r.Member.Name,
+			Response: r.Response,
+			Guests:   r.Guests,
+			Host:     r.Member.EventContext.Host,
+			Answers:  answers,
+			Updated:  time.Unix(r.Updated/1000, 0),
+		})
+	}
+	return rsvps, nil
+}
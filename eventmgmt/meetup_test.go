@@ -0,0 +1,34 @@
+package eventmgmt
+
+import "testing"
+
+func Test_nextPageLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "Last page", header: ""},
+		{
+			name:   "Next page",
+			header: `<https://api.meetup.com/group/events?page=20&offset=1>; rel="next"`,
+			want:   "https://api.meetup.com/group/events?page=20&offset=1",
+		},
+		{
+			name:   "Next and previous pages with commas in links",
+			header: `<https://api.meetup.com/group/events?fields=a,b&offset=0>; rel="prev", <https://api.meetup.com/group/events?fields=a,b&offset=2>; rel="next"`,
+			want:   "https://api.meetup.com/group/events?fields=a,b&offset=2",
+		},
+		{
+			name:   "Only previous page",
+			header: `<https://api.meetup.com/group/events?offset=0>; rel="prev"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextPageLink(tt.header); got != tt.want {
+				t.Errorf("nextPageLink() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
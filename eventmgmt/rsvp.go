@@ -0,0 +1,101 @@
+package eventmgmt
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Responses of RSVPs. Members on the waitlist are moved to yes when spots free up
+const (
+	RSVPYes      = "yes"
+	RSVPNo       = "no"
+	RSVPWaitlist = "waitlist"
+)
+
+// RSVP is the response of a member to an event
+type RSVP struct {
+	MemberID string `json:"member_id"`
+	Name     string `json:"name"`
+	Response string `json:"response"`
+	// Guests are the additional people that the member brings along
+	Guests  int          `json:"guests"`
+	Host    bool         `json:"host"`
+	Answers []RSVPAnswer `json:"answers"`
+	// Updated is when the member last changed the RSVP
+	Updated time.Time `json:"updated"`
+}
+
+// RSVPAnswer is the answer of a member to a question that is asked on RSVP
+type RSVPAnswer struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// RSVPSummary are the counts of RSVPs by response. Attendees is the number of people expected
+// to turn up, i.e. members that are going along with their guests
+type RSVPSummary struct {
+	Yes       int `json:"yes"`
+	No        int `json:"no"`
+	Waitlist  int `json:"waitlist"`
+	Guests    int `json:"guests"`
+	Attendees int `json:"attendees"`
+}
+
+// SummarizeRSVPs counts the RSVPs by response. Only guests of members that are going are counted
+func SummarizeRSVPs(rsvps []RSVP) RSVPSummary {
+	var s RSVPSummary
+	for _, r := range rsvps {
+		switch r.Response {
+		case RSVPYes:
+			s.Yes++
+			s.Guests += r.Guests
+		case RSVPNo:
+			s.No++
+		case RSVPWaitlist:
+			s.Waitlist++
+		}
+	}
+	s.Attendees = s.Yes + s.Guests
+	return s
+}
+
+// WriteRSVPsCSV writes the RSVPs with a column for each RSVP question, in the order the
+// questions are first seen
+func WriteRSVPsCSV(w io.Writer, rsvps []RSVP) error {
+	questions := []string{}
+	seen := map[string]bool{}
+	for _, r := range rsvps {
+		for _, a := range r.Answers {
+			if !seen[a.Question] {
+				seen[a.Question] = true
+				questions = append(questions, a.Question)
+			}
+		}
+	}
+	cw := csv.NewWriter(w)
+	header := append([]string{"Member ID", "Name", "Response", "Guests", "Host", "Updated"}, questions...)
+	cw.Write(header)
+	for _, r := range rsvps {
+		host := ""
+		if r.Host {
+			host = "yes"
+		}
+		updated := ""
+		if !r.Updated.IsZero() {
+			updated = r.Updated.Format(time.RFC3339)
+		}
+		row := []string{r.MemberID, r.Name, r.Response, strconv.Itoa(r.Guests), host, updated}
+		answers := map[string]string{}
+		for _, a := range r.Answers {
+			answers[a.Question] = a.Answer
+		}
+		for _, q := range questions {
+			row = append(row, answers[q])
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,80 @@
+package eventmgmt
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_parseMeetupRSVPs(t *testing.T) {
+	raw := `[
+  {"created": 1602000000000, "updated": 1602086400000, "response": "yes", "guests": 1,
+   "member": {"id": 101, "name": "Jane Doe", "event_context": {"host": true}},
+   "answers": [{"question_id": 1, "question": "Company", "answer": "Example"}]},
+  {"created": 1602000000000, "updated": 1602000000000, "response": "waitlist", "guests": 0,
+   "member": {"id": 102, "name": "John Doe", "event_context": {"host": false}}}
+]`
+	got, err := parseMeetupRSVPs([]byte(raw))
+	if err != nil {
+		t.Fatalf("parseMeetupRSVPs() error = %v", err)
+	}
+	want := []RSVP{
+		{MemberID: "101", Name: "Jane Doe", Response: RSVPYes, Guests: 1, Host: true, Answers: []RSVPAnswer{{Question: "Company", Answer: "Example"}}, Updated: time.Unix(1602086400, 0)},
+		{MemberID: "102", Name: "John Doe", Response: RSVPWaitlist, Answers: []RSVPAnswer{}, Updated: time.Unix(1602000000, 0)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseMeetupRSVPs() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSummarizeRSVPs(t *testing.T) {
+	tests := []struct {
+		name  string
+		rsvps []RSVP
+		want  RSVPSummary
+	}{
+		{
+			name: "No rsvps",
+			want: RSVPSummary{},
+		},
+		{
+			name: "Only guests of members that are going are counted",
+			rsvps: []RSVP{
+				{Response: RSVPYes, Guests: 2},
+				{Response: RSVPYes},
+				{Response: RSVPNo, Guests: 1},
+				{Response: RSVPWaitlist, Guests: 1},
+			},
+			want: RSVPSummary{Yes: 2, No: 1, Waitlist: 1, Guests: 2, Attendees: 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SummarizeRSVPs(tt.rsvps); got != tt.want {
+				t.Errorf("SummarizeRSVPs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteRSVPsCSV(t *testing.T) {
+	rsvps := []RSVP{
+		{MemberID: "101", Name: "Jane Doe", Response: RSVPYes, Guests: 1, Host: true, Updated: time.Date(2020, 10, 7, 16, 0, 0, 0, time.UTC),
+			Answers: []RSVPAnswer{{Question: "Company", Answer: "Example, Inc"}}},
+		{MemberID: "102", Name: "John Doe", Response: RSVPNo,
+			Answers: []RSVPAnswer{{Question: "Dietary needs", Answer: "Vegetarian"}}},
+	}
+	var buf bytes.Buffer
+	err := WriteRSVPsCSV(&buf, rsvps)
+	if err != nil {
+		t.Fatalf("WriteRSVPsCSV() error = %v", err)
+	}
+	want := `Member ID,Name,Response,Guests,Host,Updated,Company,Dietary needs
+101,Jane Doe,yes,1,yes,2020-10-07T16:00:00Z,"Example, Inc",
+102,John Doe,no,0,,,,Vegetarian
+`
+	if buf.String() != want {
+		t.Errorf("WriteRSVPsCSV() = %v, want %v", buf.String(), want)
+	}
+}
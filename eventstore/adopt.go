@@ -0,0 +1,178 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/eventmgmt"
+	"github.com/hairizuanbinnoorazman/techmeetup/streaming"
+)
+
+// WithAdoptConfirm sets the function that is asked before an existing event on a platform
+// is adopted, e.g. an interactive prompt on the CLI. Declined adoptions are skipped so that
+// duplicates are not created. Matches are adopted without asking if not set
+func WithAdoptConfirm(confirm func(c Change) bool) Option {
+	return func(s *EventStore) {
+		s.adoptConfirm = confirm
+	}
+}
+
+func (s EventStore) confirmAdopt(c Change) bool {
+	if s.adoptConfirm == nil {
+		return true
+	}
+	return s.adoptConfirm(c)
+}
+
+// adoptListings holds the existing streamyard broadcasts and upcoming meetup events that events
+// are matched against for adoption. Each listing is read at most once per sync and is shared by
+// the workers that sync events concurrently, so listings are accessed one at a time
+type adoptListings struct {
+	mu           sync.Mutex
+	streams      []streaming.Stream
+	meetupEvents []eventmgmt.Event
+	// streamsListed and meetupEventsListed are set once the listings are read, as either
+	// listing may be empty
+	streamsListed      bool
+	meetupEventsListed bool
+}
+
+// listStreams returns the existing broadcasts on streamyard. They are listed on every call if
+// there are no listings, i.e. outside of a sync
+func (l *adoptListings) listStreams(ctx context.Context, streamyardSvc streaming.Streamyard) ([]streaming.Stream, error) {
+	if l == nil {
+		return streamyardSvc.ListStreams(ctx)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.streamsListed {
+		return l.streams, nil
+	}
+	streams, err := streamyardSvc.ListStreams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l.streams = streams
+	l.streamsListed = true
+	return streams, nil
+}
+
+// listMeetupEvents returns the upcoming events on meetup. They are listed on every call if
+// there are no listings, i.e. outside of a sync
+func (l *adoptListings) listMeetupEvents(ctx context.Context, meetupClient eventmgmt.Meetup) ([]eventmgmt.Event, error) {
+	if l == nil {
+		return meetupClient.ListUpcomingEvents(ctx)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.meetupEventsListed {
+		return l.meetupEvents, nil
+	}
+	meetupEvents, err := meetupClient.ListUpcomingEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l.meetupEvents = meetupEvents
+	l.meetupEventsListed = true
+	return meetupEvents, nil
+}
+
+// isMatch is true if the event on the platform has the same title and start time as the event
+func isMatch(e Event, title string, startTime time.Time) bool {
+	return strings.EqualFold(strings.TrimSpace(title), strings.TrimSpace(e.Title)) && startTime.Equal(e.StartDate)
+}
+
+// matchStream finds the streamyard broadcast of the event among the existing broadcasts.
+// More than one match is an error as it is not possible to tell which to adopt
+func matchStream(streams []streaming.Stream, e Event) (streaming.Stream, bool, error) {
+	matches := []streaming.Stream{}
+	for _, st := range streams {
+		if isMatch(e, st.Name, st.StartDate) {
+			matches = append(matches, st)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return streaming.Stream{}, false, nil
+	case 1:
+		return matches[0], true, nil
+	default:
+		return streaming.Stream{}, false, fmt.Errorf("Found %v streamyard broadcasts with the same title and start time. Please set the streamyard_id of the event", len(matches))
+	}
+}
+
+// matchMeetupEvent finds the meetup event of the event among the upcoming meetup events.
+// More than one match is an error as it is not possible to tell which to adopt
+func matchMeetupEvent(meetupEvents []eventmgmt.Event, e Event) (eventmgmt.Event, bool, error) {
+	matches := []eventmgmt.Event{}
+	for _, me := range meetupEvents {
+		if isMatch(e, me.Name, me.StartTime) {
+			matches = append(matches, me)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return eventmgmt.Event{}, false, nil
+	case 1:
+		return matches[0], true, nil
+	default:
+		return eventmgmt.Event{}, false, fmt.Errorf("Found %v meetup events with the same title and start time. Please set the meetup_id of the event", len(matches))
+	}
+}
+
+// adoptStream plans the adoption of an existing streamyard broadcast of the event
+func (s *EventStore) adoptStream(ctx context.Context, c Change, e Event) (Change, bool, error) {
+	streams, err := s.adoptListings.listStreams(ctx, s.streamyardSvc)
+	if err != nil {
+		return c, false, fmt.Errorf("Unable to list streams on streamyard. Err: %v", err)
+	}
+	st, found, err := matchStream(streams, e)
+	if err != nil || !found {
+		return c, false, err
+	}
+	youtubeLink := ""
+	for _, dest := range st.Destinations {
+		if dest.Type == "youtube" {
+			youtubeLink = dest.Link
+		}
+	}
+	c.Action = ActionAdopt
+	c.Reason = "Found an existing broadcast with the same title and start time"
+	c.diff("streamyard_id", "", st.ID)
+	c.diff("youtube_link", "", youtubeLink)
+	return c, true, nil
+}
+
+// adoptMeetupEvent plans the adoption of an existing meetup event of the event
+func (s *EventStore) adoptMeetupEvent(ctx context.Context, c Change, e Event) (Change, bool, error) {
+	meetupEvents, err := s.adoptListings.listMeetupEvents(ctx, s.meetupClient)
+	if err != nil {
+		return c, false, fmt.Errorf("Unable to list upcoming events on meetup. Err: %v", err)
+	}
+	me, found, err := matchMeetupEvent(meetupEvents, e)
+	if err != nil || !found {
+		return c, false, err
+	}
+	c.Action = ActionAdopt
+	c.Reason = "Found an existing meetup event with the same title and start time"
+	c.diff("meetup_id", "", me.ID)
+	return c, true, nil
+}
+
+// adopted writes the platform IDs of the adopted event back into the event
+func adopted(e Event, c Change) Event {
+	for _, d := range c.Diffs {
+		switch d.Field {
+		case "streamyard_id":
+			e.StreamyardID = d.After
+		case "youtube_link":
+			e.YoutubeLink = d.After
+		case "meetup_id":
+			e.MeetupID = d.After
+		}
+	}
+	return e
+}
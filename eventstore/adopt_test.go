@@ -0,0 +1,325 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/hairizuanbinnoorazman/techmeetup/eventmgmt"
+	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+	"github.com/hairizuanbinnoorazman/techmeetup/streaming"
+)
+
+// redirectTransport sends all requests to the test server, whatever the host of the request
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (rt redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = rt.target.Scheme
+	r.URL.Host = rt.target.Host
+	r.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// listCalls counts the listings of the fake platforms by the number of first pages served
+type listCalls struct {
+	streams      int32
+	meetupEvents int32
+}
+
+// platformClientsHelper sets up the streamyard and meetup clients against a fake of both
+// platforms. Broadcasts are served in pages of the streamyard page size and meetup events of the
+// test-group in pages of 2 events, which can also be retrieved by ID. Listings are counted in
+// calls if set
+func platformClientsHelper(t *testing.T, broadcasts []streaming.StreamyardBroadcastResponse, meetupEvents []eventmgmt.MeetupEventResp, calls *listCalls) (streaming.Streamyard, eventmgmt.Meetup) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/broadcasts":
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			if calls != nil && offset == 0 {
+				atomic.AddInt32(&calls.streams, 1)
+			}
+			resp := streaming.StreamyardListResponse{Broadcasts: []streaming.StreamyardBroadcastResponse{}}
+			for idx := offset; idx < offset+limit && idx < len(broadcasts); idx++ {
+				resp.Broadcasts = append(resp.Broadcasts, broadcasts[idx])
+			}
+			resp.HasMore = offset+limit < len(broadcasts)
+			json.NewEncoder(w).Encode(resp)
+		case r.Method == http.MethodGet && r.URL.Path == "/test-group/events":
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			if calls != nil && offset == 0 {
+				atomic.AddInt32(&calls.meetupEvents, 1)
+			}
+			page := []eventmgmt.MeetupEventResp{}
+			for idx := offset * 2; idx < offset*2+2 && idx < len(meetupEvents); idx++ {
+				page = append(page, meetupEvents[idx])
+			}
+			if offset*2+2 < len(meetupEvents) {
+				next := *r.URL
+				next.Scheme, next.Host = "https", "api.meetup.com"
+				q := next.Query()
+				q.Set("offset", strconv.Itoa(offset+1))
+				next.RawQuery = q.Encode()
+				w.Header().Set("Link", fmt.Sprintf("<%v>; rel=\"next\"", next.String()))
+			}
+			json.NewEncoder(w).Encode(page)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/test-group/events/"):
+			for _, me := range meetupEvents {
+				if r.URL.Path == "/test-group/events/"+me.ID {
+					json.NewEncoder(w).Encode(me)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	target, _ := url.Parse(srv.URL)
+	client := &http.Client{Transport: redirectTransport{target: target}}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": time.Now().Add(24 * time.Hour).Unix()}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("Unable to sign jwt. Err: %v", err)
+	}
+	l := logger.LoggerForTests{Tester: t}
+	return streaming.NewStreamyard(l, client, "csrf", token, "user", "", ""), eventmgmt.NewMeetup(l, client, "test-group", "token", nil)
+}
+
+func Test_matchStream(t *testing.T) {
+	startDate := time.Date(2099, 10, 15, 19, 30, 0, 0, time.FixedZone("SGT", 8*60*60))
+	e := Event{Title: "Webinar 78 - Observability", StartDate: startDate}
+	tests := []struct {
+		name      string
+		streams   []streaming.Stream
+		wantID    string
+		wantFound bool
+		wantErr   bool
+	}{
+		{
+			name: "Same title and start time in another time zone",
+			streams: []streaming.Stream{
+				{ID: "other", Name: "Webinar 77", StartDate: startDate.UTC()},
+				{ID: "match", Name: "webinar 78 - observability ", StartDate: startDate.UTC()},
+			},
+			wantID:    "match",
+			wantFound: true,
+		},
+		{
+			name:    "Different start time",
+			streams: []streaming.Stream{{ID: "match", Name: "Webinar 78 - Observability", StartDate: startDate.Add(time.Hour)}},
+		},
+		{
+			name: "Multiple matches",
+			streams: []streaming.Stream{
+				{ID: "a", Name: "Webinar 78 - Observability", StartDate: startDate},
+				{ID: "b", Name: "Webinar 78 - Observability", StartDate: startDate},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found, err := matchStream(tt.streams, e)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("matchStream() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if found != tt.wantFound || got.ID != tt.wantID {
+				t.Errorf("matchStream() = %v %v, want %v %v", got.ID, found, tt.wantID, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestEventStore_Sync_Adopt(t *testing.T) {
+	startDate := time.Date(2099, 10, 15, 19, 30, 0, 0, time.FixedZone("SGT", 8*60*60))
+	// The existing events are past the first page of each platform
+	broadcasts := []streaming.StreamyardBroadcastResponse{}
+	meetupEvents := []eventmgmt.MeetupEventResp{}
+	for i := 1; i <= 12; i++ {
+		other := startDate.AddDate(0, 0, -7*i)
+		broadcasts = append(broadcasts, streaming.StreamyardBroadcastResponse{
+			ID:      fmt.Sprintf("other-%v", i),
+			Title:   fmt.Sprintf("Webinar %v", 78-i),
+			Outputs: []streaming.StreamyardBroadcastOutputResponse{{PlannedStartTime: other.UTC().Format("2006-01-02T15:04:05Z")}},
+		})
+		meetupEvents = append(meetupEvents, eventmgmt.MeetupEventResp{ID: fmt.Sprintf("other-%v", i), Name: fmt.Sprintf("Webinar %v", 78-i), Time: other.Unix() * 1000})
+	}
+	broadcasts = append(broadcasts, streaming.StreamyardBroadcastResponse{
+		ID:    "stream-1",
+		Title: "Webinar 78 - Observability",
+		Outputs: []streaming.StreamyardBroadcastOutputResponse{{
+			ID:               "dest-1",
+			Platform:         "youtube",
+			PlatformLink:     "https://youtu.be/abc",
+			PlannedStartTime: startDate.UTC().Format("2006-01-02T15:04:05Z"),
+		}},
+	})
+	meetupEvents = append(meetupEvents, eventmgmt.MeetupEventResp{ID: "meetup-1", Name: "Webinar 78 - Observability", Time: startDate.Unix() * 1000})
+	tests := []struct {
+		name             string
+		opts             SyncOptions
+		confirm          func(c Change) bool
+		wantActions      []Action
+		wantStreamyardID string
+		wantMeetupID     string
+	}{
+		{
+			name:        "Dry run",
+			opts:        SyncOptions{DryRun: true},
+			wantActions: []Action{ActionSkip, ActionAdopt, ActionAdopt, ActionSkip, ActionSkip, ActionSkip, ActionSkip},
+		},
+		{
+			name:             "Adopted IDs are written back",
+			wantActions:      []Action{ActionSkip, ActionAdopt, ActionAdopt, ActionSkip, ActionSkip, ActionSkip, ActionSkip},
+			wantStreamyardID: "stream-1",
+			wantMeetupID:     "meetup-1",
+		},
+		{
+			name:        "Declined adoptions are skipped",
+			confirm:     func(c Change) bool { return false },
+			wantActions: []Action{ActionSkip, ActionSkip, ActionSkip, ActionSkip, ActionSkip, ActionSkip, ActionSkip},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := yamlStoreHelper(t, fmt.Sprintf(`- id: webinar-78
+  track_event: true
+  start_date: "2099-10-15T19:30:00+08:00"
+  title: Webinar 78 - Observability
+  description: Some description
+  featured_image_path: %v
+  is_online: true
+  duration: 90
+  organizers:
+  - name: Organizer
+    email: organizer@example.com
+`, imageHelper(t)))
+			s := EventStore{
+				store:          store,
+				logger:         logger.LoggerForTests{Tester: t},
+				featureControl: SubMeetupFeatureControl{StreamyardSync: true, MeetupSync: true},
+				adoptConfirm:   tt.confirm,
+			}
+			s.streamyardSvc, s.meetupClient = platformClientsHelper(t, broadcasts, meetupEvents, nil)
+			got, err := s.Sync(context.TODO(), tt.opts)
+			if err != nil {
+				t.Fatalf("EventStore.Sync() error = %v", err)
+			}
+			if len(got.Changes) != len(tt.wantActions) {
+				t.Fatalf("EventStore.Sync() = %v changes, want %v", len(got.Changes), len(tt.wantActions))
+			}
+			for idx, c := range got.Changes {
+				if c.Action != tt.wantActions[idx] {
+					t.Errorf("EventStore.Sync() %v action = %v, want %v", c.Platform, c.Action, tt.wantActions[idx])
+				}
+			}
+			e, err := store.Get("webinar-78")
+			if err != nil {
+				t.Fatalf("YAMLFileStore.Get() error = %v", err)
+			}
+			if e.StreamyardID != tt.wantStreamyardID || e.MeetupID != tt.wantMeetupID {
+				t.Errorf("Event IDs = %v %v, want %v %v", e.StreamyardID, e.MeetupID, tt.wantStreamyardID, tt.wantMeetupID)
+			}
+			if tt.wantStreamyardID != "" && e.YoutubeLink != "https://youtu.be/abc" {
+				t.Errorf("Event youtube link = %v, want https://youtu.be/abc", e.YoutubeLink)
+			}
+		})
+	}
+}
+
+func TestEventStore_Sync_AdoptListsOnce(t *testing.T) {
+	events := ""
+	for i := 78; i <= 81; i++ {
+		events += fmt.Sprintf(`- id: webinar-%v
+  track_event: true
+  start_date: "2099-10-%vT19:30:00+08:00"
+  title: Webinar %v
+  description: Some description
+  featured_image_path: %v
+  is_online: true
+  duration: 90
+  organizers:
+  - name: Organizer
+    email: organizer@example.com
+`, i, i-63, i, imageHelper(t))
+	}
+	calls := &listCalls{}
+	s := EventStore{
+		store:          yamlStoreHelper(t, events),
+		logger:         logger.LoggerForTests{Tester: t},
+		featureControl: SubMeetupFeatureControl{StreamyardSync: true, MeetupSync: true},
+		workers:        3,
+	}
+	s.streamyardSvc, s.meetupClient = platformClientsHelper(t, nil, nil, calls)
+	// Each sync lists the platforms again so that events created in between are adopted
+	for run := int32(1); run <= 2; run++ {
+		_, err := s.Sync(context.TODO(), SyncOptions{DryRun: true})
+		if err != nil {
+			t.Fatalf("EventStore.Sync() error = %v", err)
+		}
+		if calls.streams != run || calls.meetupEvents != run {
+			t.Errorf("EventStore.Sync() run %v listed streams %v and meetup events %v times, want %v", run, calls.streams, calls.meetupEvents, run)
+		}
+	}
+}
+
+func TestEventStore_Plan_StreamyardListFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		code int
+	}{
+		{name: "Response is not ok", body: `{"error": "unauthorized"}`, code: http.StatusUnauthorized},
+		{name: "Response can't be parsed", body: `<html></html>`, code: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.code)
+				w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(srv.Close)
+			target, _ := url.Parse(srv.URL)
+			token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": time.Now().Add(24 * time.Hour).Unix()}).SignedString([]byte("secret"))
+			streamyardSvc := streaming.NewStreamyard(logger.LoggerForTests{Tester: t}, &http.Client{Transport: redirectTransport{target: target}}, "csrf", token, "user", "", "")
+			s := EventStore{
+				store: yamlStoreHelper(t, fmt.Sprintf(`- id: webinar-78
+  track_event: true
+  start_date: "2099-10-15T19:30:00+08:00"
+  title: Webinar 78 - Observability
+  description: Some description
+  featured_image_path: %v
+  is_online: true
+  duration: 90
+  organizers:
+  - name: Organizer
+    email: organizer@example.com
+`, imageHelper(t))),
+				logger:         logger.LoggerForTests{Tester: t},
+				featureControl: SubMeetupFeatureControl{StreamyardSync: true},
+				streamyardSvc:  streamyardSvc,
+			}
+			got, err := s.Sync(context.TODO(), SyncOptions{DryRun: true, Platform: "streamyard"})
+			if err != nil {
+				t.Fatalf("EventStore.Sync() error = %v", err)
+			}
+			if len(got.Changes) != 1 || got.Changes[0].Action == ActionCreate || got.Changes[0].Error == "" {
+				t.Errorf("EventStore.Sync() = %+v, want the plan to fail instead of creating a broadcast", got.Changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/eventmgmt"
+)
+
+// RSVPs lists the RSVPs on meetup of the event with the ID or title. RSVPs of all responses are
+// returned if no responses are provided
+func (s EventStore) RSVPs(ctx context.Context, idOrTitle string, responses ...string) (Event, []eventmgmt.RSVP, error) {
+	data, err := s.ListEvents()
+	if err != nil {
+		return Event{}, nil, err
+	}
+	matches := filterEvents(data, idOrTitle)
+	if len(matches) == 0 {
+		return Event{}, nil, fmt.Errorf("Unable to find event in eventstore. Event: %v", idOrTitle)
+	}
+	e := matches[0]
+	if e.MeetupID == "" {
+		return e, nil, fmt.Errorf("Event is not on meetup yet. Event: %v", e.Title)
+	}
+	rsvps, err := s.meetupClient.ListRSVPs(ctx, e.MeetupID, responses...)
+	if err != nil {
+		return e, nil, fmt.Errorf("Unable to retrieve rsvps from meetup. Err: %v MeetupID: %v", err, e.MeetupID)
+	}
+	return e, rsvps, nil
+}
@@ -0,0 +1,152 @@
+package eventstore
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Result of a change that was applied to a platform
+const (
+	AuditResultSuccess = "success"
+	AuditResultError   = "error"
+)
+
+// AuditEntry is a single change that was applied to a platform
+type AuditEntry struct {
+	Time      time.Time   `json:"time"`
+	EventID   string      `json:"event_id"`
+	Title     string      `json:"title"`
+	Platform  string      `json:"platform"`
+	Operation Action      `json:"operation"`
+	Diffs     []FieldDiff `json:"diffs,omitempty"`
+	Result    string      `json:"result"`
+	Error     string      `json:"error,omitempty"`
+}
+
+func newAuditEntry(c Change, now time.Time) AuditEntry {
+	entry := AuditEntry{
+		Time:      now,
+		EventID:   c.EventID,
+		Title:     c.Title,
+		Platform:  c.Platform,
+		Operation: c.Action,
+		Diffs:     c.Diffs,
+		Result:    AuditResultSuccess,
+	}
+	if c.Error != "" {
+		entry.Result = AuditResultError
+		entry.Error = c.Error
+	}
+	return entry
+}
+
+// AuditLog is an append only journal of the changes applied to the platforms. Each entry
+// is written as a single line of json
+type AuditLog struct {
+	filePath string
+	mu       sync.Mutex
+}
+
+func NewAuditLog(f string) *AuditLog {
+	return &AuditLog{
+		filePath: f,
+	}
+}
+
+// WithAuditLog records every change applied to the platforms into the audit log
+func WithAuditLog(a *AuditLog) Option {
+	return func(s *EventStore) {
+		s.auditLog = a
+	}
+}
+
+// Append adds the entry to the end of the audit log
+func (a *AuditLog) Append(entry AuditEntry) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal audit entry. Err: %v", err)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	f, err := os.OpenFile(a.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("Unable to open audit log. Err: %v", err)
+	}
+	if _, err := f.Write(append(raw, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("Unable to write audit entry. Err: %v", err)
+	}
+	return f.Close()
+}
+
+// AuditQuery narrows down the entries of the audit log. Empty fields are not used for filtering
+type AuditQuery struct {
+	// Event is the ID or title of the event
+	Event string
+	// From and To are the range of time of the entries, inclusive
+	From time.Time
+	To   time.Time
+}
+
+func (q AuditQuery) matches(entry AuditEntry) bool {
+	if q.Event != "" && entry.EventID != q.Event && entry.Title != q.Event {
+		return false
+	}
+	if !q.From.IsZero() && entry.Time.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && entry.Time.After(q.To) {
+		return false
+	}
+	return true
+}
+
+// Query returns the entries in the audit log that match the query in the order that they
+// were recorded. An audit log that does not exist yet has no entries
+func (a *AuditLog) Query(q AuditQuery) ([]AuditEntry, error) {
+	entries := []AuditEntry{}
+	f, err := os.Open(a.filePath)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open audit log. Err: %v", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry AuditEntry
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to parse line %v of audit log. Err: %v", line, err)
+		}
+		if q.matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Unable to read audit log. Err: %v", err)
+	}
+	return entries, nil
+}
+
+// audit records the applied change in the audit log if there is one
+func (s EventStore) audit(c Change) {
+	if s.auditLog == nil {
+		return
+	}
+	err := s.auditLog.Append(newAuditEntry(c, time.Now()))
+	if err != nil {
+		s.logger.Errorf("Unable to record change in audit log. Event: %v Platform: %v Err: %v", c.Title, c.Platform, err)
+	}
+}
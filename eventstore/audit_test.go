@@ -0,0 +1,74 @@
+package eventstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAuditLog_Query(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatalf("Unable to create temp dir. Err: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	a := NewAuditLog(filepath.Join(dir, "audit.jsonl"))
+
+	day := time.Date(2021, 1, 31, 10, 0, 0, 0, time.UTC)
+	changes := []Change{
+		{EventID: "a", Title: "Webinar A", Platform: "meetup", Action: ActionCreate, Diffs: []FieldDiff{{Field: "title", After: "Webinar A"}}, Applied: true},
+		{EventID: "b", Title: "Webinar B", Platform: "calendar", Action: ActionUpdate, Error: "Unable to update"},
+		{EventID: "a", Title: "Webinar A", Platform: "streamyard", Action: ActionDelete, Applied: true},
+	}
+	for idx, c := range changes {
+		err := a.Append(newAuditEntry(c, day.AddDate(0, 0, idx)))
+		if err != nil {
+			t.Fatalf("AuditLog.Append() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		query         AuditQuery
+		wantPlatforms []string
+	}{
+		{
+			name:          "All entries",
+			wantPlatforms: []string{"meetup", "calendar", "streamyard"},
+		},
+		{
+			name:          "By event title",
+			query:         AuditQuery{Event: "Webinar A"},
+			wantPlatforms: []string{"meetup", "streamyard"},
+		},
+		{
+			name:          "By date range",
+			query:         AuditQuery{From: day.AddDate(0, 0, 1), To: day.AddDate(0, 0, 1)},
+			wantPlatforms: []string{"calendar"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.Query(tt.query)
+			if err != nil {
+				t.Fatalf("AuditLog.Query() error = %v", err)
+			}
+			if len(got) != len(tt.wantPlatforms) {
+				t.Fatalf("AuditLog.Query() = %v entries, want %v", len(got), len(tt.wantPlatforms))
+			}
+			for idx, entry := range got {
+				if entry.Platform != tt.wantPlatforms[idx] {
+					t.Errorf("AuditLog.Query() platform = %v, want %v", entry.Platform, tt.wantPlatforms[idx])
+				}
+				if entry.Platform == "calendar" && (entry.Result != AuditResultError || entry.Error == "") {
+					t.Errorf("AuditLog.Query() result = %v %v, want error", entry.Result, entry.Error)
+				}
+				if entry.Platform == "meetup" && (entry.Result != AuditResultSuccess || len(entry.Diffs) != 1) {
+					t.Errorf("AuditLog.Query() result = %v diffs = %v, want success with 1 diff", entry.Result, entry.Diffs)
+				}
+			}
+		})
+	}
+}
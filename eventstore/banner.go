@@ -0,0 +1,144 @@
+package eventstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/bannergen"
+)
+
+// bannerInputs are the values that are rendered onto the banner image
+type bannerInputs struct {
+	seriesName    string
+	webinarTitle  string
+	formattedTime string
+	// speakers are the names of the speakers of the event, comma separated
+	speakers string
+}
+
+func newBannerInputs(e Event) (bannerInputs, error) {
+	items := strings.SplitN(e.Title, "-", 2)
+	if len(items) != 2 {
+		return bannerInputs{}, fmt.Errorf("Unable to split title to series name + title name. Title: %v", e.Title)
+	}
+	endTime := e.StartDate.Add(time.Duration(e.Duration) * time.Minute)
+	return bannerInputs{
+		seriesName:    strings.Trim(items[0], " "),
+		webinarTitle:  strings.Trim(items[1], " "),
+		formattedTime: fmt.Sprintf("%v to %v", e.StartDate.Format("2 January 2006 - 15:04pm"), endTime.Format("15:04pm")),
+		speakers:      speakerNames(e.Speakers()),
+	}, nil
+}
+
+// bannerHash is a hash of everything that affects the rendered banner image. A change in hash
+// means that the banner image needs to be regenerated
+func (s *EventStore) bannerHash(b bannerInputs) (string, error) {
+	template, err := ioutil.ReadFile(s.bannerTemplate)
+	if err != nil {
+		return "", fmt.Errorf("Unable to read banner template. Err: %v", err)
+	}
+	h := sha256.New()
+	for _, item := range []string{b.seriesName, b.webinarTitle, b.formattedTime} {
+		h.Write([]byte(item))
+		h.Write([]byte{0})
+	}
+	// Speakers are only part of the hash when there are speakers so that banners of events
+	// without speakers are not regenerated
+	if b.speakers != "" {
+		h.Write([]byte(b.speakers))
+		h.Write([]byte{0})
+	}
+	h.Write(template)
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+func (s *EventStore) planBanner(ctx context.Context, e Event) Change {
+	c := newChange(e, "banner")
+	if !s.featureControl.GenerateBannerImageSync {
+		return c.skip("Generate Banner Image sync is disabled")
+	}
+
+	if time.Now().After(e.StartDate) {
+		return c.skip("Start Date Time is already past. We will no longer track this event for this Autogenerating banner image")
+	}
+
+	if !e.IsOnline && !e.IsInPerson() {
+		return c.skip("Event is neither online nor held at a venue. We will skip this workflow for now")
+	}
+
+	if e.Status == StatusCancelled {
+		return c.skip("Event is cancelled")
+	}
+
+	if !e.GenerateBannerImage {
+		return c.skip("Generating Banner Image is disabled")
+	}
+
+	if e.Title == "" || e.StartDate.IsZero() || e.Duration == 0 {
+		return c.skip("Missing title, start date or duration")
+	}
+
+	e, err := s.withSpeakers(e)
+	if err != nil {
+		return c.fail(err)
+	}
+	inputs, err := newBannerInputs(e)
+	if err != nil {
+		return c.skip(err.Error())
+	}
+	hash, err := s.bannerHash(inputs)
+	if err != nil {
+		return c.fail(err)
+	}
+	if hash == e.BannerHash && e.FeaturedImagePath != "" {
+		return c
+	}
+
+	c.Action = ActionUpdate
+	if e.BannerHash == "" {
+		c.Action = ActionCreate
+	}
+	c.diff("banner_hash", e.BannerHash, hash)
+	c.diff("featured_image_path", e.FeaturedImagePath, s.bannerPath(e, hash))
+	return c
+}
+
+func (s *EventStore) applyBanner(ctx context.Context, e Event, c Change) (Event, error) {
+	resolved, err := s.withSpeakers(e)
+	if err != nil {
+		return e, err
+	}
+	inputs, err := newBannerInputs(resolved)
+	if err != nil {
+		return e, err
+	}
+	hash, err := s.bannerHash(inputs)
+	if err != nil {
+		return e, err
+	}
+	outputPath := s.bannerPath(e, hash)
+	err = bannergen.Generate_banner(outputPath, inputs.seriesName, inputs.webinarTitle, inputs.formattedTime, inputs.speakers)
+	if err != nil {
+		return e, fmt.Errorf("Generating banner failed.\n  Err: %v\n  seriesName: %v\n  webinarTitle: %v\n  formattedTime: %v", err, inputs.seriesName, inputs.webinarTitle, inputs.formattedTime)
+	}
+
+	e.FeaturedImagePath = outputPath
+	e.BannerHash = hash
+	e.UpdateImageOnPlatforms = true
+	return e, nil
+}
+
+func expectedBanner(e Event) Event {
+	e.FeaturedImagePath = knownAfterApply
+	e.UpdateImageOnPlatforms = true
+	return e
+}
+
+func (s *EventStore) bannerPath(e Event, hash string) string {
+	return filepath.Join(s.bannerOutputDir, fmt.Sprintf("%v-%v.png", e.ID, hash[:8]))
+}
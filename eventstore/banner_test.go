@@ -0,0 +1,84 @@
+package eventstore
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+)
+
+func TestEventStore_planBanner(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "banner")
+	defer os.RemoveAll(dir)
+	template := filepath.Join(dir, "template.html")
+	ioutil.WriteFile(template, []byte("<div id=\"banner\">{{ .WebinarTitle }}</div>"), 0644)
+
+	loc, _ := time.LoadLocation("Asia/Singapore")
+	event := Event{
+		ID:                  "webinar-78",
+		Title:               "Webinar #78 - Observability",
+		StartDate:           time.Now().Add(48 * time.Hour).In(loc).Truncate(time.Minute),
+		Duration:            90,
+		IsOnline:            true,
+		GenerateBannerImage: true,
+	}
+	s := &EventStore{
+		logger:          logger.LoggerForTests{Tester: t},
+		featureControl:  SubMeetupFeatureControl{GenerateBannerImageSync: true},
+		bannerOutputDir: dir,
+		bannerTemplate:  template,
+	}
+	inputs, _ := newBannerInputs(event)
+	currentHash, _ := s.bannerHash(inputs)
+
+	tests := []struct {
+		name       string
+		event      func(e Event) Event
+		wantAction Action
+	}{
+		{
+			name:       "No banner generated yet",
+			event:      func(e Event) Event { return e },
+			wantAction: ActionCreate,
+		},
+		{
+			name: "Banner is up to date",
+			event: func(e Event) Event {
+				e.BannerHash = currentHash
+				e.FeaturedImagePath = "banner.png"
+				return e
+			},
+			wantAction: ActionNoop,
+		},
+		{
+			name: "Title changed",
+			event: func(e Event) Event {
+				e.BannerHash = currentHash
+				e.FeaturedImagePath = "banner.png"
+				e.Title = "Webinar #78 - Tracing"
+				return e
+			},
+			wantAction: ActionUpdate,
+		},
+		{
+			name: "Title cannot be split",
+			event: func(e Event) Event {
+				e.Title = "Observability"
+				return e
+			},
+			wantAction: ActionSkip,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.planBanner(context.TODO(), tt.event(event))
+			if got.Action != tt.wantAction {
+				t.Errorf("EventStore.planBanner() = %+v, want action %v", got, tt.wantAction)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package eventstore
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"gopkg.in/yaml.v2"
+)
+
+var eventsBucket = []byte("events")
+
+// BoltStore keeps each event as its own key in an embedded bolt database.
+// The database is only opened for the duration of each operation so that the cli
+// commands can still access it while the server is running. Operations within the same
+// process are serialized rather than waiting on the file lock of the database
+type BoltStore struct {
+	filePath string
+	timeout  time.Duration
+	mu       *sync.Mutex
+}
+
+func NewBoltStore(f string) BoltStore {
+	return BoltStore{
+		filePath: f,
+		timeout:  10 * time.Second,
+		mu:       &sync.Mutex{},
+	}
+}
+
+func (b BoltStore) List() ([]Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	db, err := b.open()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	data := []Event{}
+	err = db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket)
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			var e Event
+			if err := yaml.Unmarshal(v, &e); err != nil {
+				return fmt.Errorf("Unable to parse event %v. Err: %v", string(k), err)
+			}
+			data = append(data, e)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (b BoltStore) Get(id string) (Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	db, err := b.open()
+	if err != nil {
+		return Event{}, err
+	}
+	defer db.Close()
+
+	var e Event
+	err = db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket)
+		if bucket == nil {
+			return ErrEventNotFound
+		}
+		raw := bucket.Get([]byte(id))
+		if raw == nil {
+			return ErrEventNotFound
+		}
+		return yaml.Unmarshal(raw, &e)
+	})
+	if err != nil {
+		return Event{}, err
+	}
+	return e, nil
+}
+
+func (b BoltStore) Put(e Event) error {
+	if e.ID == "" {
+		return fmt.Errorf("Event ID is missing. Title: %v", e.Title)
+	}
+	raw, err := yaml.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal event. Err: %v", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	db, err := b.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(eventsBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(e.ID), raw)
+	})
+}
+
+func (b BoltStore) Delete(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	db, err := b.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket)
+		if bucket == nil || bucket.Get([]byte(id)) == nil {
+			return ErrEventNotFound
+		}
+		return bucket.Delete([]byte(id))
+	})
+}
+
+func (b BoltStore) open() (*bolt.DB, error) {
+	db, err := bolt.Open(b.filePath, 0600, &bolt.Options{Timeout: b.timeout})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open bolt eventstore. Err: %v", err)
+	}
+	return db, nil
+}
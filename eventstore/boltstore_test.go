@@ -0,0 +1,94 @@
+package eventstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func boltStoreHelper(t *testing.T) BoltStore {
+	dir, err := ioutil.TempDir("", "eventstore")
+	if err != nil {
+		t.Fatalf("Unable to create temp dir. Err: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return NewBoltStore(filepath.Join(dir, "events.db"))
+}
+
+func TestBoltStore_PutGet(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Singapore")
+	tests := []struct {
+		name    string
+		events  []Event
+		getID   string
+		want    Event
+		wantErr bool
+	}{
+		{
+			name: "Successful case",
+			events: []Event{
+				{ID: "a", Title: "First", StartDate: time.Date(2020, 10, 15, 19, 30, 0, 0, loc), StreamyardID: "s1"},
+				{ID: "b", Title: "Second", StartDate: time.Date(2020, 10, 29, 19, 30, 0, 0, loc)},
+			},
+			getID: "a",
+			want:  Event{ID: "a", Title: "First", StartDate: time.Date(2020, 10, 15, 19, 30, 0, 0, loc), StreamyardID: "s1"},
+		},
+		{
+			name: "Overwrite existing event",
+			events: []Event{
+				{ID: "a", Title: "First", StartDate: time.Date(2020, 10, 15, 19, 30, 0, 0, loc)},
+				{ID: "a", Title: "First", StartDate: time.Date(2020, 10, 15, 19, 30, 0, 0, loc), MeetupID: "m1"},
+			},
+			getID: "a",
+			want:  Event{ID: "a", Title: "First", StartDate: time.Date(2020, 10, 15, 19, 30, 0, 0, loc), MeetupID: "m1"},
+		},
+		{
+			name:    "Missing event",
+			getID:   "a",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boltStoreHelper(t)
+			for _, e := range tt.events {
+				if err := b.Put(e); err != nil {
+					t.Fatalf("BoltStore.Put() error = %v", err)
+				}
+			}
+			got, err := b.Get(tt.getID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BoltStore.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.ID != tt.want.ID || got.Title != tt.want.Title || got.MeetupID != tt.want.MeetupID || got.StreamyardID != tt.want.StreamyardID || !got.StartDate.Equal(tt.want.StartDate) {
+				t.Errorf("BoltStore.Get() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoltStore_ListDelete(t *testing.T) {
+	b := boltStoreHelper(t)
+	if got, err := b.List(); err != nil || len(got) != 0 {
+		t.Fatalf("BoltStore.List() = %v, %v on empty store", got, err)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		b.Put(Event{ID: id, Title: id, StartDate: time.Now().Truncate(time.Second)})
+	}
+	if err := b.Delete("b"); err != nil {
+		t.Errorf("BoltStore.Delete() error = %v", err)
+	}
+	if err := b.Delete("b"); err != ErrEventNotFound {
+		t.Errorf("BoltStore.Delete() error = %v, want %v", err, ErrEventNotFound)
+	}
+	got, err := b.List()
+	if err != nil {
+		t.Fatalf("BoltStore.List() error = %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "a" || got[1].ID != "c" {
+		t.Errorf("BoltStore.List() = %+v", got)
+	}
+}
@@ -0,0 +1,126 @@
+package eventstore
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DescriptionTemplates are go templates that render the description of an event for each
+// platform from the event, its agenda and speakers. Platforms without a template would use
+// the description of the event as is. The calendar invite would fall back to the calendar
+// event invitation in config
+//
+// The event fields and methods (e.g. .Title, .Agenda, .Speakers, .Chapters) as well as .StreamyardLink
+// and .Venue are available in the templates
+type DescriptionTemplates struct {
+	Meetup     string `yaml:"meetup"`
+	Streamyard string `yaml:"streamyard"`
+	Calendar   string `yaml:"calendar"`
+	// Youtube is the description of the recording once the event is over. It defaults to the
+	// description followed by the agenda, speaker profiles, slides and chapters of the event
+	Youtube string `yaml:"youtube"`
+}
+
+const defaultYoutubeTemplate = `{{ .Description }}
+{{ range .Agenda }}{{ if eq .Type "speaker" }}
+{{ .Topic }}
+{{ range .Speakers }}- {{ .Name }}{{ if .Profile }}: {{ .Profile }}{{ end }}
+{{ end }}{{ if .SlidesLink }}Slides: {{ .SlidesLink }}
+{{ end }}{{ end }}{{ end }}{{ with .Chapters }}
+Chapters
+{{ range . }}{{ . }}
+{{ end }}{{ end }}`
+
+func (d DescriptionTemplates) forPlatform(platform string) string {
+	switch platform {
+	case "meetup":
+		return d.Meetup
+	case "streamyard":
+		return d.Streamyard
+	case "calendar":
+		return d.Calendar
+	case "youtube":
+		if d.Youtube == "" {
+			return defaultYoutubeTemplate
+		}
+		return d.Youtube
+	default:
+		return ""
+	}
+}
+
+// WithDescriptionTemplates sets the templates used to render the descriptions on each platform
+func WithDescriptionTemplates(d DescriptionTemplates) Option {
+	return func(s *EventStore) {
+		s.descriptionTemplates = d
+	}
+}
+
+// Speakers returns all speakers in the agenda of the event in the order that they appear.
+// Speakers with multiple talks are only returned once
+func (e Event) Speakers() []Speaker {
+	seen := map[Speaker]bool{}
+	speakers := []Speaker{}
+	for _, a := range e.Agenda {
+		for _, sp := range a.Speakers {
+			if seen[sp] {
+				continue
+			}
+			seen[sp] = true
+			speakers = append(speakers, sp)
+		}
+	}
+	return speakers
+}
+
+type descriptionData struct {
+	Event
+	StreamyardLink string
+	Venue          Venue
+}
+
+var descriptionFuncs = template.FuncMap{
+	"join":         strings.Join,
+	"speakerNames": speakerNames,
+}
+
+func speakerNames(speakers []Speaker) string {
+	names := []string{}
+	for _, sp := range speakers {
+		names = append(names, sp.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// description renders the description of the event for the platform
+func (s EventStore) description(e Event, platform string) (string, error) {
+	streamyardLink := fmt.Sprintf("https://streamyard.com/%v", e.StreamyardID)
+	raw := s.descriptionTemplates.forPlatform(platform)
+	if raw == "" {
+		// Invites to join the stream only make sense for events that are streamed
+		if platform == "calendar" && e.IsOnline {
+			return fmt.Sprintf(s.calendarEventInvite, streamyardLink), nil
+		}
+		return e.Description, nil
+	}
+	tmpl, err := template.New(platform).Funcs(descriptionFuncs).Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("Unable to parse %v description template. Err: %v", platform, err)
+	}
+	v, err := s.venue(e)
+	if err != nil {
+		return "", err
+	}
+	e, err = s.withSpeakers(e)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	err = tmpl.Execute(&b, descriptionData{Event: e, StreamyardLink: streamyardLink, Venue: v})
+	if err != nil {
+		return "", fmt.Errorf("Unable to render %v description for event %v. Err: %v", platform, e.Title, err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
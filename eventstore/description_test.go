@@ -0,0 +1,65 @@
+package eventstore
+
+import (
+	"testing"
+)
+
+func TestEventStore_description(t *testing.T) {
+	event := Event{
+		Title:        "Webinar 78 - Observability",
+		Description:  "Free text description",
+		IsOnline:     true,
+		StreamyardID: "abc",
+		Agenda: []AgendaItem{
+			{Type: "speaker", Topic: "Tracing", Synopsis: "All about tracing", Speakers: []Speaker{{Name: "Alice", Profile: "SRE"}}},
+			{Type: "break"},
+			{Type: "speaker", Topic: "Metrics", Speakers: []Speaker{{Name: "Alice", Profile: "SRE"}, {Name: "Bob"}}},
+		},
+	}
+	tests := []struct {
+		name      string
+		templates DescriptionTemplates
+		platform  string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:     "No template uses event description",
+			platform: "meetup",
+			want:     "Free text description",
+		},
+		{
+			name:     "No calendar template uses calendar invite",
+			platform: "calendar",
+			want:     "Join via https://streamyard.com/abc",
+		},
+		{
+			name: "Agenda and speakers rendered",
+			templates: DescriptionTemplates{Meetup: `{{ .Description }}
+{{ range .Agenda }}{{ if eq .Type "speaker" }}
+{{ .Topic }} by {{ speakerNames .Speakers }}{{ end }}{{ end }}
+{{ range .Speakers }}
+{{ .Name }}{{ if .Profile }}: {{ .Profile }}{{ end }}{{ end }}`},
+			platform: "meetup",
+			want:     "Free text description\n\nTracing by Alice\nMetrics by Alice, Bob\n\nAlice: SRE\nBob",
+		},
+		{
+			name:      "Invalid template",
+			templates: DescriptionTemplates{Streamyard: `{{ .Unknown }}`},
+			platform:  "streamyard",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := EventStore{calendarEventInvite: "Join via %v", descriptionTemplates: tt.templates}
+			got, err := s.description(event, tt.platform)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EventStore.description() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("EventStore.description() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
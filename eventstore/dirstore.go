@@ -0,0 +1,185 @@
+package eventstore
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DirStore keeps each event in its own yaml file (<event id>.yaml) within a directory so that
+// organizers editing different events do not run into merge conflicts. Only the file of the
+// event that changed is written
+type DirStore struct {
+	dir string
+	mu  sync.Mutex
+	// written is the hash of the content last written to each file so that changes made by
+	// the store itself can be told apart from changes made by organizers
+	written map[string][sha256.Size]byte
+}
+
+func NewDirStore(dir string) *DirStore {
+	return &DirStore{
+		dir:     dir,
+		written: map[string][sha256.Size]byte{},
+	}
+}
+
+// Dir is the directory that the events are kept in
+func (d *DirStore) Dir() string {
+	return d.dir
+}
+
+// WroteLast is true if the file still holds the content that the store last wrote to it, i.e.
+// the file has not been changed by anyone else since
+func (d *DirStore) WroteLast(path string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	hash, ok := d.written[filepath.Clean(path)]
+	if !ok {
+		return false
+	}
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return sha256.Sum256(raw) == hash
+}
+
+// IsEventFile is true for files that hold an event in a directory eventstore
+func IsEventFile(path string) bool {
+	if strings.HasPrefix(filepath.Base(path), ".") {
+		return false
+	}
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// ReadEventFile reads a single event file of a directory eventstore. Events without an ID are
+// identified by the name of their file
+func ReadEventFile(path string) (Event, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Event{}, err
+	}
+	var e Event
+	err = yaml.Unmarshal(raw, &e)
+	if err != nil {
+		return Event{}, fmt.Errorf("Issue with unmarshalling %v. Err: %v", path, err)
+	}
+	if e.ID == "" {
+		e.ID = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return e, nil
+}
+
+func (d *DirStore) List() ([]Event, error) {
+	files, err := d.files()
+	if err != nil {
+		return nil, err
+	}
+	data := []Event{}
+	for _, f := range files {
+		e, err := ReadEventFile(f)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, e)
+	}
+	return data, nil
+}
+
+func (d *DirStore) Get(id string) (Event, error) {
+	path, err := d.find(id)
+	if err != nil {
+		return Event{}, err
+	}
+	return ReadEventFile(path)
+}
+
+func (d *DirStore) Put(e Event) error {
+	if e.ID == "" {
+		return fmt.Errorf("Event ID is missing. Title: %v", e.Title)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	path, err := d.find(e.ID)
+	if err == ErrEventNotFound {
+		path = filepath.Join(d.dir, e.ID+".yaml")
+	} else if err != nil {
+		return err
+	}
+	rawData, err := yaml.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal event %v. Err: %v", e.ID, err)
+	}
+	err = writeFileAtomic(path, rawData)
+	if err != nil {
+		return err
+	}
+	d.written[filepath.Clean(path)] = sha256.Sum256(rawData)
+	return nil
+}
+
+func (d *DirStore) Delete(id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	path, err := d.find(id)
+	if err != nil {
+		return err
+	}
+	delete(d.written, filepath.Clean(path))
+	return os.Remove(path)
+}
+
+// files lists the event files in the directory in name order
+func (d *DirStore) files() ([]string, error) {
+	entries, err := ioutil.ReadDir(d.dir)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read eventstore directory. Err: %v", err)
+	}
+	files := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() || !IsEventFile(entry.Name()) {
+			continue
+		}
+		files = append(files, filepath.Join(d.dir, entry.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// find returns the file of the event. The file is usually named after the event ID but events
+// that set their own ID may be kept in a file with any name
+func (d *DirStore) find(id string) (string, error) {
+	for _, ext := range []string{".yaml", ".yml"} {
+		path := filepath.Join(d.dir, id+ext)
+		if e, err := ReadEventFile(path); err == nil && e.ID == id {
+			return path, nil
+		}
+	}
+	files, err := d.files()
+	if err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		e, err := ReadEventFile(f)
+		if err != nil {
+			return "", err
+		}
+		if e.ID == id {
+			return f, nil
+		}
+	}
+	return "", ErrEventNotFound
+}
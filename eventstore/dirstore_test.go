@@ -0,0 +1,135 @@
+package eventstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func dirStoreHelper(t *testing.T, files map[string]string) *DirStore {
+	dir, err := ioutil.TempDir("", "eventstore")
+	if err != nil {
+		t.Fatalf("Unable to create temp dir. Err: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	for name, content := range files {
+		ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+	}
+	return NewDirStore(dir)
+}
+
+var sampleEventFiles = map[string]string{
+	"webinar-78.yaml": `track_event: true
+start_date: "2020-10-15T19:30:00+08:00"
+title: Webinar 78 - Observability
+description: Some description
+duration: 90
+`,
+	"serverless.yml": `id: custom-id
+track_event: false
+start_date: "2020-10-29T19:30:00+08:00"
+title: Webinar 79 - Serverless
+description: Some description
+duration: 90
+`,
+	"README.md": "Not an event",
+}
+
+func TestDirStore_List(t *testing.T) {
+	d := dirStoreHelper(t, sampleEventFiles)
+	got, err := d.List()
+	if err != nil {
+		t.Fatalf("DirStore.List() error = %v", err)
+	}
+	wantIDs := []string{"custom-id", "webinar-78"}
+	if len(got) != len(wantIDs) {
+		t.Fatalf("DirStore.List() = %v events, want %v", len(got), len(wantIDs))
+	}
+	for idx, e := range got {
+		if e.ID != wantIDs[idx] {
+			t.Errorf("DirStore.List() ID = %v, want %v", e.ID, wantIDs[idx])
+		}
+	}
+}
+
+func TestDirStore_Put(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     func(d *DirStore) Event
+		wantFile  string
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "Update event kept in a file with another name",
+			event: func(d *DirStore) Event {
+				e, _ := d.Get("custom-id")
+				e.MeetupID = "123"
+				return e
+			},
+			wantFile:  "serverless.yml",
+			wantCount: 2,
+		},
+		{
+			name: "Add new event",
+			event: func(d *DirStore) Event {
+				e, _ := d.Get("custom-id")
+				e.ID = "another-id"
+				e.MeetupID = "123"
+				return e
+			},
+			wantFile:  "another-id.yaml",
+			wantCount: 3,
+		},
+		{
+			name: "Missing id",
+			event: func(d *DirStore) Event {
+				return Event{Title: "No ID"}
+			},
+			wantCount: 2,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := dirStoreHelper(t, sampleEventFiles)
+			untouched := filepath.Join(d.dir, "webinar-78.yaml")
+			e := tt.event(d)
+			if err := d.Put(e); (err != nil) != tt.wantErr {
+				t.Fatalf("DirStore.Put() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			all, _ := d.List()
+			if len(all) != tt.wantCount {
+				t.Errorf("DirStore.Put() count = %v, want %v", len(all), tt.wantCount)
+			}
+			raw, _ := ioutil.ReadFile(untouched)
+			if string(raw) != sampleEventFiles["webinar-78.yaml"] {
+				t.Errorf("DirStore.Put() rewrote the file of another event: %v", string(raw))
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := ReadEventFile(filepath.Join(d.dir, tt.wantFile))
+			if err != nil {
+				t.Fatalf("ReadEventFile() error = %v", err)
+			}
+			if got.ID != e.ID || got.MeetupID != e.MeetupID || !got.StartDate.Equal(e.StartDate) {
+				t.Errorf("ReadEventFile() = %+v, want %+v", got, e)
+			}
+		})
+	}
+}
+
+func TestDirStore_Delete(t *testing.T) {
+	d := dirStoreHelper(t, sampleEventFiles)
+	if err := d.Delete("webinar-78"); err != nil {
+		t.Fatalf("DirStore.Delete() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(d.dir, "webinar-78.yaml")); !os.IsNotExist(err) {
+		t.Errorf("DirStore.Delete() file still exists. Err: %v", err)
+	}
+	if err := d.Delete("webinar-78"); err != ErrEventNotFound {
+		t.Errorf("DirStore.Delete() error = %v, want %v", err, ErrEventNotFound)
+	}
+}
@@ -3,17 +3,19 @@ package eventstore
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
+	"net/url"
+	"reflect"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hairizuanbinnoorazman/techmeetup/streaming"
 
-	"github.com/hairizuanbinnoorazman/techmeetup/bannergen"
 	"github.com/hairizuanbinnoorazman/techmeetup/calendar"
 	"github.com/hairizuanbinnoorazman/techmeetup/eventmgmt"
 	"github.com/hairizuanbinnoorazman/techmeetup/logger"
-	"gopkg.in/yaml.v2"
+	"github.com/hairizuanbinnoorazman/techmeetup/youtube"
 )
 
 type SubMeetupFeatureControl struct {
@@ -28,19 +30,92 @@ type SubMeetupFeatureControl struct {
 }
 
 type EventStore struct {
-	eventstoreFile      string
-	calendarID          string
-	calendarEventInvite string
-	meetupClient        eventmgmt.Meetup
-	logger              logger.Logger
-	calendarSvc         calendar.GoogleCalendar
-	streamyardSvc       streaming.Streamyard
-	featureControl      SubMeetupFeatureControl
-}
-
-func NewEventStore(l logger.Logger, eventMgmt eventmgmt.Meetup, calendarSvc calendar.GoogleCalendar, streamyardSvc streaming.Streamyard, eventStoreFile, calendarID, calendarEventInvite string, featureControl SubMeetupFeatureControl) EventStore {
-	return EventStore{
-		eventstoreFile:      eventStoreFile,
+	store                Store
+	calendarID           string
+	calendarEventInvite  string
+	meetupClient         eventmgmt.Meetup
+	logger               logger.Logger
+	calendarSvc          calendar.GoogleCalendar
+	streamyardSvc        streaming.Streamyard
+	featureControl       SubMeetupFeatureControl
+	bannerOutputDir      string
+	bannerTemplate       string
+	location             *time.Location
+	freezeWindow         *FreezeWindow
+	series               []Series
+	descriptionTemplates DescriptionTemplates
+	venues               map[string]Venue
+	speakers             map[string]Speaker
+	// meetupVenueIDs caches the meetup venue ID of each venue
+	meetupVenueIDs *venueCache
+	// workers is the number of events that are synced concurrently
+	workers int
+	// syncTimeout is the deadline for each sync run. No deadline if 0
+	syncTimeout time.Duration
+	// auditLog records the changes applied to the platforms. Not recorded if nil
+	auditLog *AuditLog
+	// adoptConfirm is asked before existing events on platforms are adopted
+	adoptConfirm func(c Change) bool
+	// adoptListings are the existing events on platforms that are listed once per sync
+	adoptListings *adoptListings
+	// youtubeSvc updates the recordings of events once they are over
+	youtubeSvc youtube.Youtube
+	// sheetsReporter maintains the spreadsheet of all events. Not maintained if nil
+	sheetsReporter *sheetsReporter
+	// slidesDeck creates the slides of events from a template. Not created if nil
+	slidesDeck *slidesDeck
+}
+
+// Option allows optional configuration of the EventStore
+type Option func(*EventStore)
+
+// WithTimeZone sets the default time zone for events that do not have their own time zone
+func WithTimeZone(loc *time.Location) Option {
+	return func(s *EventStore) {
+		if loc != nil {
+			s.location = loc
+		}
+	}
+}
+
+// WithFreezeWindow sets the default freeze window for events that do not define their own
+func WithFreezeWindow(f FreezeWindow) Option {
+	return func(s *EventStore) {
+		s.freezeWindow = &f
+	}
+}
+
+// WithWorkers sets the number of events that are synced concurrently. Defaults to 1
+func WithWorkers(workers int) Option {
+	return func(s *EventStore) {
+		s.workers = workers
+	}
+}
+
+// WithSyncTimeout sets the deadline for each sync run. Steps that are not started by the
+// deadline are reported as errors
+func WithSyncTimeout(timeout time.Duration) Option {
+	return func(s *EventStore) {
+		s.syncTimeout = timeout
+	}
+}
+
+// WithBannerGeneration sets the directory where generated banner images are saved as well as
+// the html template that is used to render them
+func WithBannerGeneration(outputDir, template string) Option {
+	return func(s *EventStore) {
+		if outputDir != "" {
+			s.bannerOutputDir = outputDir
+		}
+		if template != "" {
+			s.bannerTemplate = template
+		}
+	}
+}
+
+func NewEventStore(l logger.Logger, eventMgmt eventmgmt.Meetup, calendarSvc calendar.GoogleCalendar, streamyardSvc streaming.Streamyard, store Store, calendarID, calendarEventInvite string, featureControl SubMeetupFeatureControl, opts ...Option) EventStore {
+	s := EventStore{
+		store:               store,
 		calendarID:          calendarID,
 		calendarEventInvite: calendarEventInvite,
 		logger:              l,
@@ -48,11 +123,29 @@ func NewEventStore(l logger.Logger, eventMgmt eventmgmt.Meetup, calendarSvc cale
 		calendarSvc:         calendarSvc,
 		streamyardSvc:       streamyardSvc,
 		featureControl:      featureControl,
+		bannerOutputDir:     ".",
+		bannerTemplate:      "./templates/rocket_image.html",
+		location:            time.UTC,
+	}
+	for _, opt := range opts {
+		opt(&s)
 	}
+	return s
 }
 
+// Status of an event. Cancelled events are cancelled or removed from all platforms while
+// postponed events are expected to have their start date moved - attendees of the calendar
+// invite would be notified of the new time
+const (
+	StatusScheduled = ""
+	StatusCancelled = "cancelled"
+	StatusPostponed = "postponed"
+)
+
 type Event struct {
+	ID                     string       `yaml:"id"`
 	TrackEvent             bool         `yaml:"track_event"`
+	Status                 string       `yaml:"status"`
 	GenerateBannerImage    bool         `yaml:"generate_banner_image"`
 	UpdateImageOnPlatforms bool         `yaml:"update_image_on_platforms"`
 	FeaturedImagePath      string       `yaml:"featured_image_path"`
@@ -66,33 +159,54 @@ type Event struct {
 	StreamyardID           string       `yaml:"streamyard_id"`
 	MeetupID               string       `yaml:"meetup_id"`
 	CalendarEventID        string       `yaml:"calendar_event_id"`
+	BannerHash             string       `yaml:"banner_hash"`
 	Organizers             []Organizer  `yaml:"organizers"`
 	Agenda                 []AgendaItem `yaml:"agenda"`
 	// In minutes
 	Duration int `yaml:"duration"`
+	// TimeZone is an IANA time zone name, e.g. Asia/Singapore. Defaults to the time zone in config
+	TimeZone string `yaml:"timezone"`
+	// FreezeWindow overrides the default freeze window in config for this event
+	FreezeWindow *FreezeWindow `yaml:"freeze_window,omitempty"`
+	// SeriesID and SeriesDate are set on events that are created from a recurring series
+	SeriesID   string `yaml:"series_id,omitempty"`
+	SeriesDate string `yaml:"series_date,omitempty"`
+	// Venue is the ID of the venue of in person events. Events that are both online and
+	// have a venue are hybrid events
+	Venue string `yaml:"venue,omitempty"`
+	// SyncStatus is the outcome of the last sync of each platform. It is managed by the sync
+	SyncStatus map[string]PlatformSyncStatus `yaml:"sync_status,omitempty"`
+	// Archived is set once the recording on youtube is updated after the event. Archived
+	// events are no longer synced to youtube
+	Archived bool `yaml:"archived,omitempty"`
+	// Sponsors are shown on the slides of the event
+	Sponsors []Sponsor `yaml:"sponsors,omitempty"`
+	// SlidesID is the ID of the presentation that is created from the slides template
+	SlidesID string `yaml:"slides_id,omitempty"`
+	// SlidesText is the text, or the path of the image, that replaced each placeholder in the
+	// presentation. It is managed by the sync so that the slides are updated when it changes
+	SlidesText map[string]string `yaml:"slides_text,omitempty"`
+	// SlidesShapes is the text with placeholders of each shape of the slides by object ID so
+	// that the shapes can be filled in again when the event changes. Images that replaced a
+	// shape hold the placeholder of the image. It is managed by the sync
+	SlidesShapes map[string]string `yaml:"slides_shapes,omitempty"`
 }
 
+// Validate returns the first issue found with the event. Use ValidateEvents to retrieve all
+// issues across all events
 func (e Event) Validate() error {
-	// Sanity check - skip if any of the fields below are empty or not set right
-	if e.Title == "" || e.Description == "" {
-		return fmt.Errorf("Empty values found for entry %v", e.Title)
+	issues := validateEvent(e)
+	if len(issues) > 0 {
+		return fmt.Errorf("Invalid entry %v. %v: %v", e.Title, issues[0].Field, issues[0].Message)
 	}
-	if e.StartDate.IsZero() {
-		return fmt.Errorf("Start date is not set for entry %v", e.Title)
-	}
-	if e.Duration == 0 {
-		return fmt.Errorf("Duration of event is not set for entry %v", e.Title)
-	}
-	if len(e.Organizers) == 0 {
-		return fmt.Errorf("No organizers found for event. Do ensure that these fvalues are set")
-	}
-
 	return nil
 }
 
 func (e *Event) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	type alias struct {
+		ID                     string       `yaml:"id"`
 		TrackEvent             bool         `yaml:"track_event"`
+		Status                 string       `yaml:"status"`
 		GenerateBannerImage    bool         `yaml:"generate_banner_image"`
 		UpdateImageOnPlatforms bool         `yaml:"update_image_on_platforms"`
 		FeaturedImagePath      string       `yaml:"featured_image_path"`
@@ -106,10 +220,22 @@ func (e *Event) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		StreamyardID           string       `yaml:"streamyard_id"`
 		MeetupID               string       `yaml:"meetup_id"`
 		CalendarEventID        string       `yaml:"calendar_event_id"`
+		BannerHash             string       `yaml:"banner_hash"`
 		Organizers             []Organizer  `yaml:"organizers"`
 		Agenda                 []AgendaItem `yaml:"agenda"`
 		// In minutes
-		Duration int `yaml:"duration"`
+		Duration     int                           `yaml:"duration"`
+		TimeZone     string                        `yaml:"timezone"`
+		FreezeWindow *FreezeWindow                 `yaml:"freeze_window"`
+		SeriesID     string                        `yaml:"series_id"`
+		SeriesDate   string                        `yaml:"series_date"`
+		Venue        string                        `yaml:"venue"`
+		SyncStatus   map[string]PlatformSyncStatus `yaml:"sync_status"`
+		Archived     bool                          `yaml:"archived"`
+		Sponsors     []Sponsor                     `yaml:"sponsors"`
+		SlidesID     string                        `yaml:"slides_id"`
+		SlidesText   map[string]string             `yaml:"slides_text"`
+		SlidesShapes map[string]string             `yaml:"slides_shapes"`
 	}
 
 	var tmp alias
@@ -117,17 +243,30 @@ func (e *Event) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return err
 	}
 
-	loc, _ := time.LoadLocation("Asia/Singapore")
-	startDate, err := time.ParseInLocation("2006-01-02T15:04:05Z07:00", tmp.StartDate, loc)
+	// start_date can only be without an offset if the time zone of the event is provided
+	startDate, err := time.Parse("2006-01-02T15:04:05Z07:00", tmp.StartDate)
+	if tmp.TimeZone != "" {
+		loc, locErr := time.LoadLocation(tmp.TimeZone)
+		if locErr != nil {
+			return fmt.Errorf("Unable to load timezone: Err: %v", locErr)
+		}
+		if err != nil {
+			startDate, err = time.ParseInLocation("2006-01-02T15:04:05", tmp.StartDate, loc)
+		}
+		startDate = startDate.In(loc)
+	}
 	if err != nil {
 		return fmt.Errorf("Unable to parse dates: Err: %v", err)
 	}
 
+	e.ID = tmp.ID
 	e.TrackEvent = tmp.TrackEvent
+	e.Status = tmp.Status
 	e.GenerateBannerImage = tmp.GenerateBannerImage
 	e.FeaturedImagePath = tmp.FeaturedImagePath
 	e.UpdateImageOnPlatforms = tmp.UpdateImageOnPlatforms
 	e.StartDate = startDate
+	e.TimeZone = tmp.TimeZone
 	e.Title = tmp.Title
 	e.Description = tmp.Description
 	e.IsPublic = tmp.IsPublic
@@ -137,9 +276,20 @@ func (e *Event) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	e.StreamyardID = tmp.StreamyardID
 	e.MeetupID = tmp.MeetupID
 	e.CalendarEventID = tmp.CalendarEventID
+	e.BannerHash = tmp.BannerHash
 	e.Organizers = tmp.Organizers
 	e.Agenda = tmp.Agenda
 	e.Duration = tmp.Duration
+	e.FreezeWindow = tmp.FreezeWindow
+	e.SeriesID = tmp.SeriesID
+	e.SeriesDate = tmp.SeriesDate
+	e.Venue = tmp.Venue
+	e.SyncStatus = tmp.SyncStatus
+	e.Archived = tmp.Archived
+	e.Sponsors = tmp.Sponsors
+	e.SlidesID = tmp.SlidesID
+	e.SlidesText = tmp.SlidesText
+	e.SlidesShapes = tmp.SlidesShapes
 	return nil
 }
 
@@ -149,6 +299,12 @@ type AgendaItem struct {
 	Topic    string    `yaml:"topic"`
 	Synopsis string    `yaml:"synopsis"`
 	Speakers []Speaker `yaml:"speakers"`
+	// SpeakerIDs refer to speakers in the speakers registry
+	SpeakerIDs []string `yaml:"speaker_ids,omitempty"`
+	// Duration of the agenda item in minutes. It is used to generate the chapters of the recording
+	Duration int `yaml:"duration,omitempty"`
+	// SlidesLink is shared in the description of the recording
+	SlidesLink string `yaml:"slides_link,omitempty"`
 }
 
 type Organizer struct {
@@ -157,207 +313,647 @@ type Organizer struct {
 }
 
 type Speaker struct {
+	// ID is only needed for speakers in the speakers registry
+	ID           string `yaml:"id,omitempty"`
 	Name         string `yaml:"name"`
 	Email        string `yaml:"email"`
 	Profile      string `yaml:"profile"`
 	ProfileImage string `yaml:"profile_image"`
 }
 
+type Sponsor struct {
+	Name string `yaml:"name"`
+	// Logo is the path to the image of the logo of the sponsor
+	Logo string `yaml:"logo"`
+}
+
+// platformStep is a single sync step against a platform. plan works out what needs to be
+// done without altering anything while apply carries out the change. expected returns how the
+// event would look like after the change is applied - it is only used when planning so that the
+// later steps are able to plan against values that are only known after apply.
+type platformStep struct {
+	platform string
+	plan     func(ctx context.Context, e Event) Change
+	apply    func(ctx context.Context, e Event, c Change) (Event, error)
+	expected func(e Event) Event
+}
+
+func (s *EventStore) platformSteps() []platformStep {
+	return []platformStep{
+		{platform: "banner", plan: s.planBanner, apply: s.applyBanner, expected: expectedBanner},
+		{platform: "streamyard", plan: s.planStreamyard, apply: s.applyStreamyard, expected: expectedStreamyard},
+		{platform: "meetup", plan: s.planMeetup, apply: s.applyMeetup, expected: expectedMeetup},
+		{platform: "calendar", plan: s.planCalendar, apply: s.applyCalendar, expected: expectedCalendar},
+		{platform: "youtube", plan: s.planYoutube, apply: s.applyYoutube, expected: expectedYoutube},
+		{platform: "slides", plan: s.planSlides, apply: s.applySlides, expected: expectedSlides},
+		{platform: "sheets", plan: s.planSheets, apply: s.applySheets, expected: expectedSheets},
+	}
+}
+
+// CheckEvents would run a sync of all tracked events. In dry run mode, the plan is only logged
 func (s EventStore) CheckEvents(filterDate time.Time) error {
-	raw, err := ioutil.ReadFile(s.eventstoreFile)
+	if s.featureControl.DryRunMode {
+		p, err := s.Plan(context.Background())
+		if err != nil {
+			return err
+		}
+		s.logger.Infof("Dry run mode is enabled. The following plan will not be applied:\n%v", p)
+		return nil
+	}
+	p, err := s.Apply(context.Background(), nil)
 	if err != nil {
 		return err
 	}
-	var data []Event
-	err = yaml.Unmarshal(raw, &data)
+	s.logger.Infof("Sync completed:\n%v", p)
+	return nil
+}
+
+// SyncOptions narrows down what is synced
+type SyncOptions struct {
+	// DryRun only computes the plan without applying it
+	DryRun bool
+	// Event is the ID or title of the event to sync. All events are synced if empty
+	Event string
+	// Platform is the only platform to sync, e.g. meetup. All platforms are synced if empty
+	Platform string
+	// Approved is the plan that was approved before applying. All changes are applied if nil
+	Approved *Plan
+}
+
+// Plan computes the changes that would be made on the various platforms without applying them
+func (s EventStore) Plan(ctx context.Context) (Plan, error) {
+	return s.Sync(ctx, SyncOptions{DryRun: true})
+}
+
+// Apply computes the changes and applies them to the various platforms. If an approved plan
+// is provided, only changes that are in the approved plan are applied - changes that differ,
+// e.g. as the eventstore or a platform changed after the plan was approved, are skipped with an
+// error. The returned plan contains the outcome of each of the changes
+func (s EventStore) Apply(ctx context.Context, approved *Plan) (Plan, error) {
+	return s.Sync(ctx, SyncOptions{Approved: approved})
+}
+
+// Sync computes the changes for the events and platforms selected in opts and applies them
+// unless it is a dry run
+func (s EventStore) Sync(ctx context.Context, opts SyncOptions) (Plan, error) {
+	if opts.Platform != "" && !s.isPlatform(opts.Platform) {
+		return Plan{}, ErrUnknownPlatform
+	}
+	data, err := s.ListEvents()
 	if err != nil {
-		return fmt.Errorf("Issue with unmarshalling data. Err: %v", err)
+		return Plan{}, err
+	}
+	generated, err := s.seriesEvents(data, time.Now())
+	if err != nil {
+		return Plan{}, err
+	}
+	for _, e := range generated {
+		s.logger.Infof("Adding event %v from series %v", e.Title, e.SeriesID)
+		if !opts.DryRun {
+			s.commitEvent(e)
+		}
+		data = append(data, e)
+	}
+	invalid := map[string]ValidationIssue{}
+	venues := []Venue{}
+	for _, v := range s.venues {
+		venues = append(venues, v)
+	}
+	issues := append(ValidateEvents(data), ValidateVenues(data, venues)...)
+	issues = append(issues, ValidateSpeakers(data, s.speakerList())...)
+	for _, issue := range issues {
+		if _, ok := invalid[data[issue.Index].ID]; !ok {
+			invalid[data[issue.Index].ID] = issue
+		}
+	}
+	if opts.Event != "" {
+		data = filterEvents(data, opts.Event)
+		if len(data) == 0 {
+			return Plan{}, ErrEventNotFound
+		}
 	}
 
+	// The spreadsheet of the sheets reporter and the existing events on platforms are read once
+	// per sync rather than once per event
+	if s.sheetsReporter != nil {
+		s.sheetsReporter = s.sheetsReporter.forSync()
+	}
+	s.adoptListings = &adoptListings{}
+
+	if s.syncTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.syncTimeout)
+		defer cancel()
+	}
+
+	// Events are synced concurrently by the workers while the platforms of each event are
+	// still synced one after another. Results are kept by the position of the event so that
+	// the plan follows the order of the events in the eventstore
+	results := make([][]Change, len(data))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < s.workerCount(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = s.syncEvent(ctx, data[idx], !opts.DryRun, opts.Platform, opts.Approved)
+			}
+		}()
+	}
 	for idx, d := range data {
 		if d.TrackEvent == false {
 			s.logger.Warningf("CheckEvents is not run for the following event: %v as tracing is not turned on for it", d.Title)
 			continue
 		}
 
-		err := d.Validate()
-		if err != nil {
-			s.logger.Errorf("Error in proceeding to evaluate the following event: %v", d.Title)
+		if issue, ok := invalid[d.ID]; ok {
+			s.logger.Errorf("Event is invalid and will not be synced: %v. Run techmeetup events validate for all issues. Err: %v", d.Title, issue)
+			results[idx] = s.rejectEvent(d, issue, opts.Platform)
+			continue
 		}
 
-		tmpEvent := d
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
 
-		tmpEvent = s.createOrUpdateYoutubeStreamyard(tmpEvent)
-		data[idx].StreamyardID = tmpEvent.StreamyardID
-		data[idx].YoutubeLink = tmpEvent.YoutubeLink
+	p := Plan{Changes: []Change{}}
+	for _, changes := range results {
+		p.Changes = append(p.Changes, changes...)
+	}
+	return p, nil
+}
+
+func (s EventStore) workerCount() int {
+	if s.workers < 1 {
+		return 1
+	}
+	return s.workers
+}
 
-		tmpEvent = s.createOrUpdateMeetup(tmpEvent)
-		data[idx].MeetupID = tmpEvent.MeetupID
+// rejectEvent marks all platform steps of an invalid event as skipped
+func (s EventStore) rejectEvent(e Event, issue ValidationIssue, platform string) []Change {
+	changes := []Change{}
+	for _, step := range s.platformSteps() {
+		if platform != "" && step.platform != platform {
+			continue
+		}
+		c := newChange(e, step.platform).fail(issue)
+		c.Reason = "Event is invalid"
+		changes = append(changes, c)
+	}
+	return changes
+}
 
-		tmpEvent = s.createOrUpdateCalendar(tmpEvent)
-		data[idx].CalendarEventID = tmpEvent.CalendarEventID
+// filterEvents returns events where either the ID or title matches
+func filterEvents(data []Event, idOrTitle string) []Event {
+	filtered := []Event{}
+	for _, d := range data {
+		if d.ID == idOrTitle || d.Title == idOrTitle {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
 
-		// Cleanup for platform updates
-		data[idx].UpdateImageOnPlatforms = false
+func (s EventStore) isPlatform(platform string) bool {
+	for _, step := range s.platformSteps() {
+		if step.platform == platform {
+			return true
+		}
 	}
+	return false
+}
 
-	rawData, err := yaml.Marshal(data)
+// ListEvents returns all events in the store with their start dates set in the
+// time zone of the event
+func (s EventStore) ListEvents() ([]Event, error) {
+	data, err := s.store.List()
 	if err != nil {
-		return fmt.Errorf("Unable to marshall the yaml file. Operations may repeat")
+		return nil, err
+	}
+	for idx := range data {
+		data[idx] = s.localize(data[idx])
 	}
-	err = ioutil.WriteFile(s.eventstoreFile, rawData, 0755)
+	return data, nil
+}
 
-	return nil
+// localize moves the start date of the event into the event's own time zone or
+// the default time zone if the event does not define one
+func (s EventStore) localize(e Event) Event {
+	if e.TimeZone != "" || s.location == nil {
+		return e
+	}
+	e.StartDate = e.StartDate.In(s.location)
+	return e
 }
 
-func (s *EventStore) createOrUpdateMeetup(e Event) Event {
+func (s EventStore) syncEvent(ctx context.Context, e Event, apply bool, platform string, approved *Plan) []Change {
+	changes := []Change{}
+	for _, step := range s.platformSteps() {
+		if platform != "" && step.platform != platform {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			changes = append(changes, newChange(e, step.platform).fail(fmt.Errorf("Sync was stopped before this step. Err: %v", err)))
+			continue
+		}
+		c := step.plan(ctx, e)
+		if apply && c.Action == ActionAdopt && !s.confirmAdopt(c) {
+			c = c.skip("Adoption of the existing event was declined. Please set the platform ID of the event or remove the existing event")
+		}
+		if !c.Mutates() {
+			if apply {
+				e = s.commitSyncStatus(e, c)
+			}
+			changes = append(changes, c)
+			continue
+		}
+		// Cancellations are not frozen as they need to reach attendees as soon as possible.
+		// Adoptions only write platform IDs back to the eventstore
+		if !c.Removes() && c.Action != ActionAdopt && s.isFrozen(e, step.platform, time.Now()) {
+			s.logger.Warningf("Drift detected on %v for event: %v but changes are frozen %v before the start of the event", step.platform, e.Title, s.freezeDuration(e, step.platform))
+			c.Frozen = true
+			changes = append(changes, c)
+			continue
+		}
+		if apply && approved != nil && !approved.approves(c) {
+			s.logger.Errorf("Change to %v for event: %v differs from the approved plan and is not applied", step.platform, e.Title)
+			c.Error = "Change differs from the approved plan. Review the plan and apply again"
+			changes = append(changes, c)
+			continue
+		}
+		if !apply {
+			if c.Action == ActionAdopt {
+				e = adopted(e, c)
+			} else {
+				e = step.expected(e)
+			}
+			changes = append(changes, c)
+			continue
+		}
+
+		updated, err := step.apply(ctx, e, c)
+		if err != nil {
+			s.logger.Errorf("Unable to %v %v for event: %v. Err: %v", c.Action, step.platform, e.Title, err)
+			c.Error = err.Error()
+		} else {
+			c.Applied = true
+		}
+		s.audit(c)
+		updated = recordSyncStatus(updated, c, time.Now())
+		// Platform IDs are committed right after each step, even on partial failures, so
+		// that they are not lost if a later step fails
+		if !reflect.DeepEqual(updated, e) {
+			e = updated
+			s.commitEvent(e)
+		}
+		changes = append(changes, c)
+	}
+
+	// Cleanup for platform updates. The flag is kept if any step failed so that the image
+	// is pushed again on the next run
+	if apply && e.UpdateImageOnPlatforms && !hasErrors(changes) {
+		e.UpdateImageOnPlatforms = false
+		s.commitEvent(e)
+	}
+	return changes
+}
+
+func hasErrors(changes []Change) bool {
+	for _, c := range changes {
+		if c.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// commitSyncStatus records the sync status of the change and saves the event if the status changed
+func (s EventStore) commitSyncStatus(e Event, c Change) Event {
+	updated := recordSyncStatus(e, c, time.Now())
+	if !reflect.DeepEqual(updated.SyncStatus, e.SyncStatus) {
+		s.commitEvent(updated)
+	}
+	return updated
+}
+
+func (s EventStore) commitEvent(e Event) {
+	err := s.store.Put(e)
+	if err != nil {
+		s.logger.Errorf("Unable to save event to eventstore. Operations may repeat. Event: %v Err: %v", e.Title, err)
+	}
+}
+
+func (s *EventStore) planMeetup(ctx context.Context, e Event) Change {
+	c := newChange(e, "meetup")
 	if !s.featureControl.MeetupSync {
-		s.logger.Warning("Meetup sync is disabled")
-		return e
+		return c.skip("Meetup sync is disabled")
 	}
 
 	if time.Now().After(e.StartDate) {
-		s.logger.Warning("Start Date Time is already past. We will no longer track this event for this MeetupSync")
-		return e
+		return c.skip("Start Date Time is already past. We will no longer track this event for this MeetupSync")
 	}
 
-	if !e.IsOnline {
-		s.logger.Warning("Event is not online. We will skip this workflow for now")
-		return e
+	if e.Status == StatusCancelled {
+		if e.MeetupID == "" {
+			return c
+		}
+		meetupEvent, err := s.meetupClient.GetEvent(ctx, e.MeetupID)
+		if err != nil {
+			return c.fail(fmt.Errorf("Unable to retrieve event details from meetup. Err: %v MeetupID: %v", err, e.MeetupID))
+		}
+		if meetupEvent.Status == "cancelled" {
+			return c
+		}
+		c.Action = ActionCancel
+		c.Reason = "Event is cancelled"
+		c.diff("status", meetupEvent.Status, "cancelled")
+		return c
 	}
 
-	if e.YoutubeLink == "" || e.StreamyardID == "" {
-		s.logger.Error("Streaming svc not setup and youtube link not available. Cannot setup meetup")
-		return e
+	if !e.IsOnline && !e.IsInPerson() {
+		return c.skip("Event is neither online nor held at a venue. We will skip this workflow for now")
+	}
+
+	if e.IsOnline && (e.YoutubeLink == "" || e.StreamyardID == "") {
+		return c.skip("Streaming svc not setup and youtube link not available. Cannot setup meetup")
 	}
 
 	if e.FeaturedImagePath == "" {
-		s.logger.Error("No featured image provided. Please provide it")
-		return e
+		return c.skip("No featured image provided. Please provide it")
+	}
+
+	desc, err := s.description(e, "meetup")
+	if err != nil {
+		return c.fail(err)
+	}
+	loc, err := s.meetupLocation(ctx, e, false)
+	if err != nil {
+		return c.fail(err)
 	}
 
 	if e.MeetupID == "" {
+		adoptChange, found, err := s.adoptMeetupEvent(ctx, c, e)
+		if err != nil {
+			return c.fail(err)
+		}
+		if found {
+			return adoptChange
+		}
+		c.Action = ActionCreate
+		c.diff("title", "", e.Title)
+		c.diff("description", "", meetupDescription(desc, e))
+		c.diff("start_date", "", formatTime(e.StartDate))
+		c.diff("is_public", "", formatBool(e.IsPublic))
+		c.diff("webinar_link", "", e.YoutubeLink)
+		if e.IsInPerson() {
+			c.diff("venue", "", loc.VenueID)
+			c.diff("how_to_find_us", "", loc.HowToFindUs)
+			c.diff("rsvp_limit", "", formatInt(loc.RSVPLimit))
+		}
+		c.diff("featured_image", "", e.FeaturedImagePath)
+		return c
+	}
+
+	meetupEvent, err := s.meetupClient.GetEvent(ctx, e.MeetupID)
+	if err != nil {
+		return c.fail(fmt.Errorf("Unable to retrieve event details from meetup. Err: %v MeetupID: %v", err, e.MeetupID))
+	}
+	parsedDesc := eventmgmt.ConvertMeetupHTMLToText(meetupEvent.Description)
+	c.diff("title", meetupEvent.Name, e.Title)
+	c.diff("description", parsedDesc, meetupDescription(desc, e))
+	if !meetupEvent.StartTime.Equal(e.StartDate) {
+		c.diff("start_date", formatTime(meetupEvent.StartTime.In(e.StartDate.Location())), formatTime(e.StartDate))
+	}
+	c.diff("is_public", formatBool(meetupEvent.IsPublic), formatBool(e.IsPublic))
+	// The webinar link is only shown in how to find us for events without a venue. Events at a
+	// venue show the link in the description instead
+	if !e.IsInPerson() {
+		c.diff("webinar_link", meetupEvent.WebinarLink, e.YoutubeLink)
+	}
+	if e.IsInPerson() {
+		c.diff("venue", meetupEvent.VenueID, loc.VenueID)
+		c.diff("how_to_find_us", meetupEvent.HowToFindUs, loc.HowToFindUs)
+		c.diff("rsvp_limit", formatInt(meetupEvent.RSVPLimit), formatInt(loc.RSVPLimit))
+	}
+	if e.UpdateImageOnPlatforms {
+		c.diff("featured_image", "", e.FeaturedImagePath)
+	}
+	if len(c.Diffs) > 0 {
+		c.Action = ActionUpdate
+	}
+	return c
+}
+
+func (s *EventStore) applyMeetup(ctx context.Context, e Event, c Change) (Event, error) {
+	if c.Action == ActionCancel {
+		s.logger.Infof("Cancelling meetup event. MeetupID: %v", e.MeetupID)
+		err := s.meetupClient.CancelEvent(ctx, e.MeetupID)
+		if err != nil {
+			return e, fmt.Errorf("Unable to cancel meetup event. Err: %v", err)
+		}
+		return e, nil
+	}
+
+	if c.Action == ActionAdopt {
+		s.logger.Infof("Adopting existing meetup event for event: %v", e.Title)
+		return adopted(e, c), nil
+	}
+
+	desc, err := s.description(e, "meetup")
+	if err != nil {
+		return e, err
+	}
+	loc, err := s.meetupLocation(ctx, e, true)
+	if err != nil {
+		return e, fmt.Errorf("Unable to setup venue on meetup. Err: %v", err)
+	}
+
+	if c.Action == ActionCreate {
 		s.logger.Info("Detected that meetup link is not created for this event. Will recreate")
 		meetupOrganizers := []string{}
 		for _, o := range s.meetupClient.OrganizerMapping {
 			meetupOrganizers = append(meetupOrganizers, o)
 		}
-		resp, err := s.meetupClient.CreateDraftEvent(context.TODO(), eventmgmt.Event{
+		resp, err := s.meetupClient.CreateDraftEvent(ctx, eventmgmt.Event{
 			StartTime:   e.StartDate,
 			Name:        e.Title,
-			Description: e.Description,
-			IsWebinar:   true,
+			Description: desc,
+			IsWebinar:   e.IsOnline,
 			IsPublic:    e.IsPublic,
 			WebinarLink: e.YoutubeLink,
+			VenueID:     loc.VenueID,
+			HowToFindUs: loc.HowToFindUs,
+			RSVPLimit:   loc.RSVPLimit,
 			Duration:    120,
 			Organizers:  meetupOrganizers,
 		})
 		if err != nil {
-			s.logger.Errorf("Unable to create draft event. Err: %v", err)
-			return e
+			return e, fmt.Errorf("Unable to create draft event. Err: %v", err)
 		}
 		e.MeetupID = resp.ID
-		photoID, err := s.meetupClient.UploadPhoto(context.TODO(), resp.ID, e.FeaturedImagePath)
+		photoID, err := s.meetupClient.UploadPhoto(ctx, resp.ID, e.FeaturedImagePath)
 		if err != nil {
-			s.logger.Errorf("Unable to upload photo. Err: %v", err)
-			return e
+			return e, fmt.Errorf("Unable to upload photo. Err: %v", err)
 		}
-		_, err = s.meetupClient.UpdateEvent(context.TODO(), resp, eventmgmt.WithFeaturedPhoto(photoID))
+		_, err = s.meetupClient.UpdateEvent(ctx, resp, eventmgmt.WithFeaturedPhoto(photoID))
 		if err != nil {
-			s.logger.Errorf("Unable to update event with featured photo")
-			return e
+			return e, fmt.Errorf("Unable to update event with featured photo. Err: %v", err)
 		}
-		return e
+		return e, nil
 	}
 
-	meetupEvent, err := s.meetupClient.GetEvent(context.TODO(), e.MeetupID)
+	meetupEvent, err := s.meetupClient.GetEvent(ctx, e.MeetupID)
 	if err != nil {
-		s.logger.Errorf("Unable to retrieve event details from meetup. Err: %v MeetupID: %v", err, e.MeetupID)
-		return e
+		return e, fmt.Errorf("Unable to retrieve event details from meetup. Err: %v MeetupID: %v", err, e.MeetupID)
 	}
-	parsedDesc := eventmgmt.ConvertMeetupHTMLToText(meetupEvent.Description)
-	s.logger.Infof(`Change Detection:
-  Description: %v
-  Title: %v
-  UpdateImageOnPlatforms: %v
-`, eventmgmt.AppendYoutubeLinktoDesc(e.Description, e.YoutubeLink) != parsedDesc, meetupEvent.Name != e.Title, e.UpdateImageOnPlatforms)
-	if (eventmgmt.AppendYoutubeLinktoDesc(e.Description, e.YoutubeLink) != parsedDesc || meetupEvent.Name != e.Title) && !e.UpdateImageOnPlatforms {
-		s.logger.Info("Begin update of meetup - no image update needed")
-		meetupEvent.Description = e.Description
-		meetupEvent.Name = e.Title
-		meetupEvent.StartTime = e.StartDate
-		meetupEvent.IsPublic = e.IsPublic
-		_, err = s.meetupClient.UpdateEvent(context.TODO(), meetupEvent)
+	s.logger.Info("Begin update of meetup")
+	meetupEvent.Description = desc
+	meetupEvent.Name = e.Title
+	meetupEvent.StartTime = e.StartDate
+	meetupEvent.IsPublic = e.IsPublic
+	meetupEvent.WebinarLink = e.YoutubeLink
+	meetupEvent.VenueID = loc.VenueID
+	meetupEvent.HowToFindUs = loc.HowToFindUs
+	meetupEvent.RSVPLimit = loc.RSVPLimit
+	options := []func(url.Values){}
+	if c.hasDiff("featured_image") {
+		photoID, err := s.meetupClient.UploadPhoto(ctx, meetupEvent.ID, e.FeaturedImagePath)
 		if err != nil {
-			s.logger.Errorf("Do check functionality to make sure all is working as expected. Err: %v", err)
-			return e
+			return e, fmt.Errorf("Unable to upload photo. Err: %v", err)
 		}
-		s.logger.Info("End update of meetup - no image update needed")
-		return e
+		options = append(options, eventmgmt.WithFeaturedPhoto(photoID))
 	}
-
-	if e.UpdateImageOnPlatforms {
-		s.logger.Info("Begin update of meetup - with image update needed")
-		meetupEvent.Description = e.Description
-		meetupEvent.Name = e.Title
-		photoID, err := s.meetupClient.UploadPhoto(context.TODO(), meetupEvent.ID, e.FeaturedImagePath)
-		if err != nil {
-			s.logger.Errorf("Unable to upload photo. Err: %v", err)
-			return e
-		}
-		_, err = s.meetupClient.UpdateEvent(context.TODO(), meetupEvent, eventmgmt.WithFeaturedPhoto(photoID))
-		if err != nil {
-			s.logger.Errorf("Unable to update event with featured photo")
-			return e
-		}
-		s.logger.Info("End update of meetup - with image update needed")
+	_, err = s.meetupClient.UpdateEvent(ctx, meetupEvent, options...)
+	if err != nil {
+		return e, fmt.Errorf("Do check functionality to make sure all is working as expected. Err: %v", err)
 	}
+	s.logger.Info("End update of meetup")
+	return e, nil
+}
 
+func expectedMeetup(e Event) Event {
+	if e.MeetupID == "" {
+		e.MeetupID = knownAfterApply
+	}
 	return e
 }
 
-func (s *EventStore) createOrUpdateYoutubeStreamyard(e Event) Event {
+func (s *EventStore) planStreamyard(ctx context.Context, e Event) Change {
+	c := newChange(e, "streamyard")
 	if !s.featureControl.StreamyardSync {
-		s.logger.Warning("Streamyard sync is disabled")
-		return e
+		return c.skip("Streamyard sync is disabled")
 	}
 
 	if time.Now().After(e.StartDate) {
-		s.logger.Warning("Start Date Time is already past. We will no longer track this event for this StreamyardSync")
-		return e
+		return c.skip("Start Date Time is already past. We will no longer track this event for this StreamyardSync")
+	}
+
+	if e.Status == StatusCancelled {
+		if e.StreamyardID == "" {
+			return c
+		}
+		c.Action = ActionDelete
+		c.Reason = "Event is cancelled"
+		c.diff("streamyard_id", e.StreamyardID, "")
+		c.diff("youtube_link", e.YoutubeLink, "")
+		return c
 	}
 
 	if !e.IsOnline {
-		s.logger.Warning("Event is not online. We will skip this workflow for now")
-		return e
+		return c.skip("Event is not online. We will skip this workflow for now")
+	}
+
+	if e.StreamyardID == "" {
+		adoptChange, found, err := s.adoptStream(ctx, c, e)
+		if err != nil {
+			return c.fail(err)
+		}
+		if found {
+			return adoptChange
+		}
 	}
 
 	if e.YoutubeLink != "" && e.StreamyardID == "" {
-		s.logger.Error("Youtube link already available although streamyard link is still not available")
-		return e
+		return c.skip("Youtube link already available although streamyard link is still not available")
 	}
 
 	if e.FeaturedImagePath == "" {
-		s.logger.Error("No featured image provided. Please provide it")
-		return e
+		return c.skip("No featured image provided. Please provide it")
+	}
+
+	desc, err := s.description(e, "streamyard")
+	if err != nil {
+		return c.fail(err)
 	}
 
 	if e.StreamyardID == "" {
+		c.Action = ActionCreate
+		c.diff("title", "", e.Title)
+		c.diff("description", "", desc)
+		c.diff("start_date", "", formatTime(e.StartDate))
+		c.diff("is_public", "", formatBool(e.IsPublic))
+		c.diff("image", "", e.FeaturedImagePath)
+		return c
+	}
+
+	streamyardStream, err := s.streamyardSvc.GetStream(ctx, e.StreamyardID)
+	if err != nil {
+		return c.fail(fmt.Errorf("Unable to retrieve stream from streamyard. %v", err))
+	}
+	c.diff("title", streamyardStream.Name, e.Title)
+	c.diff("description", streamyardStream.Description, desc)
+	if !streamyardStream.StartDate.Equal(e.StartDate) {
+		c.diff("start_date", formatTime(streamyardStream.StartDate.In(e.StartDate.Location())), formatTime(e.StartDate))
+	}
+	c.diff("is_public", formatBool(streamyardStream.IsPublic), formatBool(e.IsPublic))
+	if e.UpdateImageOnPlatforms {
+		c.diff("image", "", e.FeaturedImagePath)
+	}
+	if len(c.Diffs) > 0 {
+		c.Action = ActionUpdate
+	}
+	return c
+}
+
+func (s *EventStore) applyStreamyard(ctx context.Context, e Event, c Change) (Event, error) {
+	if c.Action == ActionDelete {
+		s.logger.Infof("Deleting streamyard broadcast. StreamyardID: %v", e.StreamyardID)
+		err := s.streamyardSvc.DeleteStream(ctx, e.StreamyardID)
+		if err != nil {
+			return e, fmt.Errorf("Unable to delete stream on streamyard. Err: %v", err)
+		}
+		e.StreamyardID = ""
+		e.YoutubeLink = ""
+		return e, nil
+	}
+
+	if c.Action == ActionAdopt {
+		s.logger.Infof("Adopting existing streamyard broadcast for event: %v", e.Title)
+		return adopted(e, c), nil
+	}
+
+	desc, err := s.description(e, "streamyard")
+	if err != nil {
+		return e, err
+	}
+
+	if c.Action == ActionCreate {
 		s.logger.Info("No streamyard link available. Begin to create streamyard link")
-		streamCreateResp, err := s.streamyardSvc.CreateStream(context.TODO(), e.Title)
+		streamCreateResp, err := s.streamyardSvc.CreateStream(ctx, e.Title)
 		if err != nil {
-			s.logger.Error("Unable to create the stream on streamyard. Err: %v", err)
-			return e
+			return e, fmt.Errorf("Unable to create the stream on streamyard. Err: %v", err)
 		}
 		streamCreateResp.StartDate = e.StartDate
 		streamCreateResp.ImagePath = e.FeaturedImagePath
-		streamCreateResp.Description = e.Description
+		streamCreateResp.Description = desc
 		streamCreateResp.IsPublic = e.IsPublic
 		s.logger.Infof("Created streamyard: %+v", streamCreateResp)
-		streamDestResp, err := s.streamyardSvc.CreateDestination(context.TODO(), "youtube", streamCreateResp)
+		streamDestResp, err := s.streamyardSvc.CreateDestination(ctx, "youtube", streamCreateResp)
 		if err != nil {
-			s.logger.Error("Unable to create the output on streamyard. Err: %v", err)
-			return e
+			return e, fmt.Errorf("Unable to create the output on streamyard. Err: %v", err)
 		}
 		e.StreamyardID = streamDestResp.ID
 		for _, dest := range streamDestResp.Destinations {
@@ -366,151 +962,207 @@ func (s *EventStore) createOrUpdateYoutubeStreamyard(e Event) Event {
 			}
 		}
 		s.logger.Infof("Create of streamyard youtube stream complete. Event: %v", e)
-		return e
+		return e, nil
 	}
 
-	streamyardStream, err := s.streamyardSvc.GetStream(context.TODO(), e.StreamyardID)
+	streamyardStream, err := s.streamyardSvc.GetStream(ctx, e.StreamyardID)
 	if err != nil {
-		s.logger.Errorf("Unable to retrieve stream from streamyard. %v", err)
-		return e
+		return e, fmt.Errorf("Unable to retrieve stream from streamyard. %v", err)
 	}
 
-	s.logger.Infof("Change Detection:\n  DescriptionChange: %v\n  TitleChange: %v\n  ImageChange: %v", streamyardStream.Description != e.Description, streamyardStream.Name != e.Title, e.UpdateImageOnPlatforms)
-	if streamyardStream.Description != e.Description || streamyardStream.Name != e.Title || e.UpdateImageOnPlatforms {
-		s.logger.Info("Begin update of streamyard")
-		streamyardStream.Description = e.Description
-		streamyardStream.Name = e.Title
-		streamyardStream.ImagePath = e.FeaturedImagePath
-		streamyardStream.StartDate = e.StartDate
-		streamyardStream.IsPublic = e.IsPublic
-		s.streamyardSvc.UpdateDestination(context.TODO(), "youtube", streamyardStream, e.UpdateImageOnPlatforms)
-
-		if streamyardStream.Name != e.Title {
-			err = s.streamyardSvc.UpdateStream(context.TODO(), e.StreamyardID, e.Title)
-			if err != nil {
-				s.logger.Errorf("Unable to update stream. Err: %v", err)
-			}
+	s.logger.Info("Begin update of streamyard")
+	streamyardStream.Description = desc
+	streamyardStream.Name = e.Title
+	streamyardStream.ImagePath = e.FeaturedImagePath
+	streamyardStream.StartDate = e.StartDate
+	streamyardStream.IsPublic = e.IsPublic
+	_, err = s.streamyardSvc.UpdateDestination(ctx, "youtube", streamyardStream, c.hasDiff("image"))
+	if err != nil {
+		return e, fmt.Errorf("Unable to update youtube destination on streamyard. Err: %v", err)
+	}
+
+	if c.hasDiff("title") {
+		err = s.streamyardSvc.UpdateStream(ctx, e.StreamyardID, e.Title)
+		if err != nil {
+			return e, fmt.Errorf("Unable to update stream. Err: %v", err)
 		}
-		s.logger.Info("End update of streamyard")
 	}
+	s.logger.Info("End update of streamyard")
+	return e, nil
+}
 
+func expectedStreamyard(e Event) Event {
+	if e.StreamyardID == "" {
+		e.StreamyardID = knownAfterApply
+		e.YoutubeLink = knownAfterApply
+	}
 	return e
 }
 
-func (s *EventStore) createOrUpdateCalendar(e Event) Event {
+func (s *EventStore) planCalendar(ctx context.Context, e Event) Change {
+	c := newChange(e, "calendar")
 	if !s.featureControl.CalendarSync {
-		s.logger.Warning("Calendar sync is disabled")
-		return e
+		return c.skip("Calendar sync is disabled")
 	}
 
 	if time.Now().After(e.StartDate) {
-		s.logger.Warning("Start Date Time is already past. We will no longer track this event for this CalendarSync")
-		return e
+		return c.skip("Start Date Time is already past. We will no longer track this event for this CalendarSync")
 	}
 
-	if !e.IsOnline {
-		s.logger.Warning("Event is not online. We will skip this workflow for now")
-		return e
+	if e.Status == StatusCancelled {
+		if e.CalendarEventID == "" {
+			return c
+		}
+		calendarEvent, err := s.calendarSvc.GetEvent(ctx, s.calendarID, e.CalendarEventID)
+		if err != nil {
+			return c.fail(fmt.Errorf("Unable to retrieve calendar event. Err: %v CalendarEventID: %v", err, e.CalendarEventID))
+		}
+		if calendarEvent.Status == "cancelled" {
+			return c
+		}
+		c.Action = ActionCancel
+		c.Reason = "Event is cancelled. All attendees would be notified"
+		c.diff("status", calendarEvent.Status, "cancelled")
+		return c
 	}
 
-	if e.StreamyardID == "" || e.YoutubeLink == "" {
-		s.logger.Warning("Streamyard link and youtube link missing. Due to this, we can't aren't able to set the right calendar invite description")
-		return e
+	if !e.IsOnline && !e.IsInPerson() {
+		return c.skip("Event is neither online nor held at a venue. We will skip this workflow for now")
+	}
+
+	if e.IsOnline && (e.StreamyardID == "" || e.YoutubeLink == "") {
+		return c.skip("Streamyard link and youtube link missing. Due to this, we can't aren't able to set the right calendar invite description")
+	}
+
+	expected, err := s.calendarEvent(e)
+	if err != nil {
+		return c.fail(err)
 	}
 
 	if e.CalendarEventID == "" {
-		s.logger.Info("Detected that the calendar event id is not set - will create calendar event")
-		zz := make(map[string]bool)
-		for _, organizer := range e.Organizers {
-			zz[organizer.Email] = true
-		}
-		for _, agenda := range e.Agenda {
-			for _, speaker := range agenda.Speakers {
-				zz[speaker.Email] = true
-			}
-		}
-		yy := []string{}
-		for k := range zz {
-			yy = append(yy, k)
-		}
+		c.Action = ActionCreate
+		c.diff("title", "", expected.Title)
+		c.diff("start_time", "", formatTime(expected.StartTime))
+		c.diff("end_time", "", formatTime(expected.EndTime))
+		c.diff("description", "", expected.Description)
+		c.diff("location", "", expected.Location)
+		c.diff("attendees", "", strings.Join(expected.Attendees, ","))
+		return c
+	}
 
-		resp, err := s.calendarSvc.CreateEvent(context.TODO(), s.calendarID, calendar.CalendarEvent{
-			StartTime:   e.StartDate,
-			EndTime:     e.StartDate.Add(time.Duration(e.Duration) * time.Minute),
-			Title:       e.Title,
-			Description: fmt.Sprintf(s.calendarEventInvite, fmt.Sprintf("https://streamyard.com/%v", e.StreamyardID)),
-			Attendees:   yy,
-		})
-		if err != nil {
-			s.logger.Errorf("Unable to create calendar event. Err: %v", err)
-			return e
-		}
-		e.CalendarEventID = resp.ID
-		return e
+	calendarEvent, err := s.calendarSvc.GetEvent(ctx, s.calendarID, e.CalendarEventID)
+	if err != nil {
+		return c.fail(fmt.Errorf("Unable to retrieve calendar event. Err: %v CalendarEventID: %v", err, e.CalendarEventID))
 	}
-	return e
+	c.diff("title", calendarEvent.Title, expected.Title)
+	if !calendarEvent.StartTime.Equal(expected.StartTime) {
+		c.diff("start_time", formatTime(calendarEvent.StartTime.In(e.StartDate.Location())), formatTime(expected.StartTime))
+	}
+	if !calendarEvent.EndTime.Equal(expected.EndTime) {
+		c.diff("end_time", formatTime(calendarEvent.EndTime.In(e.StartDate.Location())), formatTime(expected.EndTime))
+	}
+	c.diff("description", calendarEvent.Description, expected.Description)
+	c.diff("location", calendarEvent.Location, expected.Location)
+	c.diff("time_zone", calendarEvent.TimeZone, expected.TimeZone)
+	currentAttendees := append([]string{}, calendarEvent.Attendees...)
+	sort.Strings(currentAttendees)
+	c.diff("attendees", strings.Join(currentAttendees, ","), strings.Join(expected.Attendees, ","))
+	if len(c.Diffs) > 0 {
+		c.Action = ActionUpdate
+	}
+	if e.Status == StatusPostponed && (c.hasDiff("start_time") || c.hasDiff("end_time")) {
+		c.Reason = "Event is postponed. All attendees would be notified"
+	}
+	return c
 }
 
-func (s *EventStore) createWebinarBannerImage(e Event) Event {
-	if !s.featureControl.GenerateBannerImageSync {
-		s.logger.Warning("Generate Banner Image sync is disabled")
-		return e
+func (s *EventStore) applyCalendar(ctx context.Context, e Event, c Change) (Event, error) {
+	if c.Action == ActionCancel {
+		s.logger.Infof("Cancelling calendar event. CalendarEventID: %v", e.CalendarEventID)
+		err := s.calendarSvc.CancelEvent(ctx, s.calendarID, e.CalendarEventID)
+		if err != nil {
+			return e, fmt.Errorf("Unable to cancel calendar event. Err: %v", err)
+		}
+		return e, nil
 	}
 
-	if time.Now().After(e.StartDate) {
-		s.logger.Warning("Start Date Time is already past. We will no longer track this event for this Autogenerating banner image")
-		return e
+	expected, err := s.calendarEvent(e)
+	if err != nil {
+		return e, err
 	}
 
-	if !e.IsOnline {
-		s.logger.Warning("Event is not online. We will skip this workflow for now")
-		return e
+	if c.Action == ActionCreate {
+		s.logger.Info("Detected that the calendar event id is not set - will create calendar event")
+		resp, err := s.calendarSvc.CreateEvent(ctx, s.calendarID, expected)
+		if err != nil {
+			return e, fmt.Errorf("Unable to create calendar event. Err: %v", err)
+		}
+		e.CalendarEventID = resp.ID
+		return e, nil
 	}
 
-	if !e.GenerateBannerImage {
-		s.logger.Warning("Generating Banner Image is disabled")
-		return e
+	s.logger.Info("Begin update of calendar event")
+	if e.Status == StatusPostponed && (c.hasDiff("start_time") || c.hasDiff("end_time")) {
+		_, err = s.calendarSvc.RescheduleEvent(ctx, s.calendarID, expected)
+	} else {
+		_, err = s.calendarSvc.UpdateEvent(ctx, s.calendarID, expected)
 	}
-
-	if e.Title == "" || e.StartDate.IsZero() || e.Duration == 0 {
-		s.logger.Warning("Missing title or start date is zero")
-		return e
+	if err != nil {
+		return e, fmt.Errorf("Unable to update calendar event. Err: %v", err)
 	}
+	s.logger.Info("End update of calendar event")
+	return e, nil
+}
 
-	items := strings.Split(e.Title, "-")
-	if len(items) != 2 {
-		s.logger.Warning("Unable to split title to series name + title name. Title: %v", e.Title)
-		return e
+func expectedCalendar(e Event) Event {
+	if e.CalendarEventID == "" {
+		e.CalendarEventID = knownAfterApply
 	}
+	return e
+}
 
-	endTime := e.StartDate.Add(time.Duration(e.Duration) * time.Minute)
-	seriesName := strings.Trim(items[0], " ")
-	webinarTitle := strings.Trim(items[1], " ")
-	formattedTime := fmt.Sprintf("%v to %v", e.StartDate.Format("2 January 2006 - 15:04pm"), endTime.Format("15:04pm"))
-	outputPath := time.Now().Format("20060102_1504") + ".png"
+// calendarEvent is the calendar invite that is expected for the event
+func (s *EventStore) calendarEvent(e Event) (calendar.CalendarEvent, error) {
+	e, err := s.withSpeakers(e)
+	if err != nil {
+		return calendar.CalendarEvent{}, err
+	}
+	desc, err := s.description(e, "calendar")
+	if err != nil {
+		return calendar.CalendarEvent{}, err
+	}
+	v, err := s.venue(e)
+	if err != nil {
+		return calendar.CalendarEvent{}, err
+	}
 
-	var streamData streaming.Stream
-	var err error
-	if e.StreamyardID != "" {
-		streamData, err = s.streamyardSvc.GetStream(context.TODO(), e.StreamyardID)
-		if err != nil {
-			s.logger.Errorf("Unable to receive stream. Err: %v", err)
-			return e
+	zz := make(map[string]bool)
+	for _, organizer := range e.Organizers {
+		if organizer.Email != "" {
+			zz[organizer.Email] = true
 		}
 	}
-
-	// Handle the following case:
-	// When streamyardID is not defined - new event being created
-	// When title on streamyard is not the same as the one being identified as the one we have
-	if streamData.Name != e.Title || e.StreamyardID == "" {
-		err = bannergen.Generate_banner(outputPath, seriesName, webinarTitle, formattedTime)
-		if err != nil {
-			s.logger.Errorf("Generating banner failed.\n  Err: %v\n  seriesName: %v\n  webinarTitle: %v\n  formattedTime: %v", err, seriesName, webinarTitle, formattedTime)
-			return e
+	for _, agenda := range e.Agenda {
+		for _, speaker := range agenda.Speakers {
+			if speaker.Email != "" {
+				zz[speaker.Email] = true
+			}
 		}
 	}
+	yy := []string{}
+	for k := range zz {
+		yy = append(yy, k)
+	}
+	sort.Strings(yy)
 
-	e.FeaturedImagePath = outputPath
-	e.UpdateImageOnPlatforms = true
-	return e
+	return calendar.CalendarEvent{
+		ID:          e.CalendarEventID,
+		StartTime:   e.StartDate,
+		EndTime:     e.StartDate.Add(time.Duration(e.Duration) * time.Minute),
+		Title:       e.Title,
+		Description: desc,
+		Location:    v.Location(),
+		Attendees:   yy,
+		TimeZone:    e.StartDate.Location().String(),
+	}, nil
 }
@@ -0,0 +1,113 @@
+package eventstore
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestEventStore_calendarEvent(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Singapore")
+	startDate := time.Date(2020, 10, 15, 19, 30, 0, 0, loc)
+	tests := []struct {
+		name          string
+		event         Event
+		wantAttendees []string
+		wantEndTime   time.Time
+	}{
+		{
+			name: "Organizers and speakers are deduplicated and sorted",
+			event: Event{
+				StartDate: startDate,
+				Duration:  90,
+				Organizers: []Organizer{
+					{Name: "B", Email: "b@example.com"},
+					{Name: "A", Email: "a@example.com"},
+				},
+				Agenda: []AgendaItem{
+					{Type: "speaker", Speakers: []Speaker{{Name: "C", Email: "c@example.com"}, {Name: "A", Email: "a@example.com"}}},
+					{Type: "break"},
+					{Type: "speaker", Speakers: []Speaker{{Name: "No email"}}},
+				},
+			},
+			wantAttendees: []string{"a@example.com", "b@example.com", "c@example.com"},
+			wantEndTime:   startDate.Add(90 * time.Minute),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &EventStore{calendarEventInvite: "Join via %v"}
+			got, err := s.calendarEvent(tt.event)
+			if err != nil {
+				t.Fatalf("EventStore.calendarEvent() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Attendees, tt.wantAttendees) {
+				t.Errorf("EventStore.calendarEvent() attendees = %v, want %v", got.Attendees, tt.wantAttendees)
+			}
+			if !got.EndTime.Equal(tt.wantEndTime) {
+				t.Errorf("EventStore.calendarEvent() end time = %v, want %v", got.EndTime, tt.wantEndTime)
+			}
+		})
+	}
+}
+
+func TestEvent_UnmarshalYAML(t *testing.T) {
+	singapore, _ := time.LoadLocation("Asia/Singapore")
+	london, _ := time.LoadLocation("Europe/London")
+	tests := []struct {
+		name         string
+		raw          string
+		wantStart    time.Time
+		wantLocation *time.Location
+		wantErr      bool
+	}{
+		{
+			name:      "Start date with offset",
+			raw:       `start_date: "2020-10-15T19:30:00+08:00"`,
+			wantStart: time.Date(2020, 10, 15, 19, 30, 0, 0, singapore),
+		},
+		{
+			name:         "Start date with offset and timezone",
+			raw:          "start_date: \"2020-10-15T19:30:00+08:00\"\ntimezone: Europe/London",
+			wantStart:    time.Date(2020, 10, 15, 12, 30, 0, 0, london),
+			wantLocation: london,
+		},
+		{
+			name:         "Local start date with timezone",
+			raw:          "start_date: \"2020-10-15T19:30:00\"\ntimezone: Europe/London",
+			wantStart:    time.Date(2020, 10, 15, 19, 30, 0, 0, london),
+			wantLocation: london,
+		},
+		{
+			name:    "Local start date without timezone",
+			raw:     `start_date: "2020-10-15T19:30:00"`,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown timezone",
+			raw:     "start_date: \"2020-10-15T19:30:00\"\ntimezone: Mars/Olympus",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e Event
+			err := yaml.Unmarshal([]byte(tt.raw), &e)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Event.UnmarshalYAML() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !e.StartDate.Equal(tt.wantStart) {
+				t.Errorf("Event.UnmarshalYAML() start date = %v, want %v", e.StartDate, tt.wantStart)
+			}
+			if tt.wantLocation != nil && e.StartDate.Location().String() != tt.wantLocation.String() {
+				t.Errorf("Event.UnmarshalYAML() location = %v, want %v", e.StartDate.Location(), tt.wantLocation)
+			}
+		})
+	}
+}
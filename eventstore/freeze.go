@@ -0,0 +1,51 @@
+package eventstore
+
+import (
+	"time"
+)
+
+// FreezeWindow stops automated changes to platforms within a period before the start of
+// an event, e.g. so that details don't change at the most critical moment. Drift is still
+// reported in the plan.
+type FreezeWindow struct {
+	// Before is the duration before the start date where changes are frozen, e.g. 2h
+	Before time.Duration `yaml:"before"`
+	// Platforms overrides Before for specific platforms, e.g. meetup: 24h
+	Platforms map[string]time.Duration `yaml:"platforms"`
+}
+
+// duration returns the freeze duration for the platform. A platform override takes
+// precedence over the general freeze duration
+func (f *FreezeWindow) duration(platform string) (time.Duration, bool) {
+	if f == nil {
+		return 0, false
+	}
+	if d, ok := f.Platforms[platform]; ok {
+		return d, true
+	}
+	if f.Before != 0 {
+		return f.Before, true
+	}
+	return 0, false
+}
+
+// freezeDuration resolves the freeze duration of the platform for an event. Values defined
+// on the event take precedence over the global default
+func (s EventStore) freezeDuration(e Event, platform string) time.Duration {
+	if d, ok := e.FreezeWindow.duration(platform); ok {
+		return d
+	}
+	d, _ := s.freezeWindow.duration(platform)
+	return d
+}
+
+// isFrozen is true if changes to the platform for the event are not allowed at this time.
+// The freeze ends once the event starts so that the event can be followed up on, e.g. the
+// recording is updated
+func (s EventStore) isFrozen(e Event, platform string, now time.Time) bool {
+	d := s.freezeDuration(e, platform)
+	if d <= 0 {
+		return false
+	}
+	return now.After(e.StartDate.Add(-d)) && now.Before(e.StartDate)
+}
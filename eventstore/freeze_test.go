@@ -0,0 +1,78 @@
+package eventstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventStore_isFrozen(t *testing.T) {
+	startDate := time.Date(2020, 10, 15, 19, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		globalWindow *FreezeWindow
+		eventWindow  *FreezeWindow
+		platform     string
+		now          time.Time
+		want         bool
+	}{
+		{
+			name:     "No freeze windows",
+			platform: "meetup",
+			now:      startDate.Add(-1 * time.Minute),
+			want:     false,
+		},
+		{
+			name:         "Within global freeze window",
+			globalWindow: &FreezeWindow{Before: 2 * time.Hour},
+			platform:     "meetup",
+			now:          startDate.Add(-1 * time.Hour),
+			want:         true,
+		},
+		{
+			name:         "Outside global freeze window",
+			globalWindow: &FreezeWindow{Before: 2 * time.Hour},
+			platform:     "meetup",
+			now:          startDate.Add(-3 * time.Hour),
+			want:         false,
+		},
+		{
+			name:         "Global platform override",
+			globalWindow: &FreezeWindow{Before: 2 * time.Hour, Platforms: map[string]time.Duration{"meetup": 24 * time.Hour}},
+			platform:     "meetup",
+			now:          startDate.Add(-3 * time.Hour),
+			want:         true,
+		},
+		{
+			name:         "Event window takes precedence over global",
+			globalWindow: &FreezeWindow{Before: 24 * time.Hour},
+			eventWindow:  &FreezeWindow{Before: 30 * time.Minute},
+			platform:     "calendar",
+			now:          startDate.Add(-1 * time.Hour),
+			want:         false,
+		},
+		{
+			name:         "Event platform override disables freeze",
+			globalWindow: &FreezeWindow{Before: 24 * time.Hour},
+			eventWindow:  &FreezeWindow{Platforms: map[string]time.Duration{"calendar": 0}},
+			platform:     "calendar",
+			now:          startDate.Add(-1 * time.Hour),
+			want:         false,
+		},
+		{
+			name:         "Freeze ends once the event starts",
+			globalWindow: &FreezeWindow{Before: 2 * time.Hour},
+			platform:     "youtube",
+			now:          startDate.Add(3 * time.Hour),
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := EventStore{freezeWindow: tt.globalWindow}
+			e := Event{StartDate: startDate, FreezeWindow: tt.eventWindow}
+			if got := s.isFrozen(e, tt.platform, tt.now); got != tt.want {
+				t.Errorf("EventStore.isFrozen() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package eventstore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const icalDateFormat = "20060102T150405"
+
+// ICalendar renders the public tracked events as an iCalendar feed that members are able to
+// subscribe to. Events keep the same UID across renders so that calendar apps update them in
+// place; cancelled events are kept in the feed so that they are marked as cancelled
+func (s EventStore) ICalendar(now time.Time) (string, error) {
+	data, err := s.ListEvents()
+	if err != nil {
+		return "", err
+	}
+	events := []Event{}
+	for _, e := range data {
+		if e.TrackEvent && e.IsPublic && !e.StartDate.IsZero() {
+			events = append(events, e)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].StartDate.Before(events[j].StartDate)
+	})
+
+	var b icalWriter
+	b.line("BEGIN:VCALENDAR")
+	b.line("VERSION:2.0")
+	b.line("PRODID:-//techmeetup//events//EN")
+	b.line("CALSCALE:GREGORIAN")
+	b.line("METHOD:PUBLISH")
+	if s.location != nil && icalZoned(s.location) {
+		b.line("X-WR-TIMEZONE:" + s.location.String())
+	}
+	for _, loc := range icalLocations(events) {
+		writeTimezone(&b, loc, events)
+	}
+	for _, e := range events {
+		v, err := s.venue(e)
+		if err != nil {
+			return "", err
+		}
+		meetupLink := ""
+		if e.MeetupID != "" {
+			meetupLink = s.meetupClient.EventLink(e.MeetupID)
+		}
+		desc := []string{e.Description}
+		if meetupLink != "" {
+			desc = append(desc, "RSVP on meetup: "+meetupLink)
+		}
+		if e.IsOnline && e.YoutubeLink != "" {
+			desc = append(desc, "Watch the livestream: "+e.YoutubeLink)
+		}
+		location := v.Location()
+		if location == "" && e.IsOnline {
+			location = e.YoutubeLink
+		}
+		url := meetupLink
+		if url == "" {
+			url = e.YoutubeLink
+		}
+		status := "CONFIRMED"
+		if e.Status == StatusCancelled {
+			status = "CANCELLED"
+		}
+
+		b.line("BEGIN:VEVENT")
+		b.line(fmt.Sprintf("UID:%v@techmeetup", e.ID))
+		b.line("DTSTAMP:" + now.UTC().Format(icalDateFormat) + "Z")
+		b.line(icalTime("DTSTART", e.StartDate))
+		b.line(icalTime("DTEND", e.StartDate.Add(time.Duration(e.Duration)*time.Minute)))
+		b.line("SUMMARY:" + icalText(e.Title))
+		b.line("DESCRIPTION:" + icalText(strings.TrimSpace(strings.Join(desc, "\n\n"))))
+		if location != "" {
+			b.line("LOCATION:" + icalText(location))
+		}
+		if url != "" {
+			b.line("URL:" + url)
+		}
+		b.line("STATUS:" + status)
+		b.line("END:VEVENT")
+	}
+	b.line("END:VCALENDAR")
+	return b.String(), nil
+}
+
+// icalWriter writes content lines, folded at 75 octets with CRLF line endings
+type icalWriter struct {
+	strings.Builder
+}
+
+func (w *icalWriter) line(content string) {
+	limit := 75
+	for len(content) > limit {
+		cut := limit
+		// Lines are not folded in the middle of a multi byte character
+		for cut > 0 && content[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.WriteString(content[:cut] + "\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space that counts towards the limit
+		limit = 74
+	}
+	w.WriteString(content + "\r\n")
+}
+
+func icalText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// icalZoned is true for time zones that are written with a TZID. Everything else is in UTC
+func icalZoned(loc *time.Location) bool {
+	return loc.String() != "UTC" && loc.String() != "Local" && loc.String() != ""
+}
+
+func icalTime(property string, t time.Time) string {
+	if !icalZoned(t.Location()) {
+		return fmt.Sprintf("%v:%vZ", property, t.UTC().Format(icalDateFormat))
+	}
+	return fmt.Sprintf("%v;TZID=%v:%v", property, t.Location().String(), t.Format(icalDateFormat))
+}
+
+func icalLocations(events []Event) []*time.Location {
+	seen := map[string]bool{}
+	locs := []*time.Location{}
+	for _, e := range events {
+		loc := e.StartDate.Location()
+		if !icalZoned(loc) || seen[loc.String()] {
+			continue
+		}
+		seen[loc.String()] = true
+		locs = append(locs, loc)
+	}
+	return locs
+}
+
+// writeTimezone writes the VTIMEZONE of the time zone. Offset changes (e.g. daylight saving)
+// are looked up across the years of the events in the time zone
+func writeTimezone(b *icalWriter, loc *time.Location, events []Event) {
+	minYear, maxYear := 0, 0
+	for _, e := range events {
+		if e.StartDate.Location().String() != loc.String() {
+			continue
+		}
+		y := e.StartDate.Year()
+		if minYear == 0 || y < minYear {
+			minYear = y
+		}
+		if y > maxYear {
+			maxYear = y
+		}
+	}
+	start := time.Date(minYear, 1, 1, 0, 0, 0, 0, loc)
+	end := time.Date(maxYear+1, 1, 1, 0, 0, 0, 0, loc)
+
+	b.line("BEGIN:VTIMEZONE")
+	b.line("TZID:" + loc.String())
+	transitions := offsetTransitions(start, end)
+	if len(transitions) == 0 {
+		name, offset := start.Zone()
+		writeObservance(b, "STANDARD", time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), name, offset, offset)
+	}
+	for _, t := range transitions {
+		_, before := t.Add(-time.Second).Zone()
+		name, after := t.Zone()
+		kind := "STANDARD"
+		if after > before {
+			kind = "DAYLIGHT"
+		}
+		// DTSTART of an observance is the local time just before the change
+		writeObservance(b, kind, t.UTC().Add(time.Duration(before)*time.Second), name, before, after)
+	}
+	b.line("END:VTIMEZONE")
+}
+
+func writeObservance(b *icalWriter, kind string, localStart time.Time, name string, from, to int) {
+	b.line("BEGIN:" + kind)
+	b.line("DTSTART:" + localStart.Format(icalDateFormat))
+	b.line("TZOFFSETFROM:" + icalOffset(from))
+	b.line("TZOFFSETTO:" + icalOffset(to))
+	b.line("TZNAME:" + name)
+	b.line("END:" + kind)
+}
+
+// offsetTransitions finds the instants between start and end where the offset from UTC changes
+func offsetTransitions(start, end time.Time) []time.Time {
+	transitions := []time.Time{}
+	_, prev := start.Zone()
+	for t := start; t.Before(end); t = t.Add(time.Hour) {
+		next := t.Add(time.Hour)
+		if _, offset := next.Zone(); offset == prev {
+			continue
+		}
+		lo, hi := t, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, offset := mid.Zone(); offset == prev {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		transitions = append(transitions, hi)
+		_, prev = next.Zone()
+	}
+	return transitions
+}
+
+func icalOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%v%02d%02d", sign, seconds/3600, (seconds%3600)/60)
+}
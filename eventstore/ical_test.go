@@ -0,0 +1,95 @@
+package eventstore
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/eventmgmt"
+	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+)
+
+func TestEventStore_ICalendar(t *testing.T) {
+	d := dirStoreHelper(t, map[string]string{
+		"webinar-78.yaml": `track_event: true
+is_public: true
+is_online: true
+start_date: "2020-10-15T19:30:00+08:00"
+title: Webinar 78 - Observability, Tracing; Logs
+description: Some description
+duration: 90
+meetup_id: "273683462"
+youtube_link: https://www.youtube.com/watch?v=abc
+`,
+		"london.yaml": `track_event: true
+is_public: true
+is_online: true
+status: cancelled
+timezone: Europe/London
+start_date: "2020-11-05T18:00:00"
+title: Webinar 79 - Serverless
+description: Some description
+duration: 60
+`,
+		"private.yaml": `track_event: true
+is_public: false
+start_date: "2020-10-29T19:30:00+08:00"
+title: Private session
+duration: 60
+`,
+		"untracked.yaml": `track_event: false
+is_public: true
+start_date: "2020-10-22T19:30:00+08:00"
+title: Untracked session
+duration: 60
+`,
+	})
+	loc, _ := time.LoadLocation("Asia/Singapore")
+	s := EventStore{
+		store:        d,
+		logger:       logger.LoggerForTests{Tester: t},
+		meetupClient: eventmgmt.NewMeetup(logger.LoggerForTests{Tester: t}, nil, "techmeetup-group", "", nil),
+	}
+	WithTimeZone(loc)(&s)
+
+	now := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	got, err := s.ICalendar(now)
+	if err != nil {
+		t.Fatalf("EventStore.ICalendar() error = %v", err)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("EventStore.ICalendar() line longer than 75 octets: %q", line)
+		}
+	}
+	unfolded := strings.Replace(got, "\r\n ", "", -1)
+	wantLines := []string{
+		"UID:webinar-78@techmeetup",
+		"DTSTAMP:20201001T000000Z",
+		"DTSTART;TZID=Asia/Singapore:20201015T193000",
+		"DTEND;TZID=Asia/Singapore:20201015T210000",
+		`SUMMARY:Webinar 78 - Observability\, Tracing\; Logs`,
+		`DESCRIPTION:Some description\n\nRSVP on meetup: https://www.meetup.com/techmeetup-group/events/273683462/\n\nWatch the livestream: https://www.youtube.com/watch?v=abc`,
+		"URL:https://www.meetup.com/techmeetup-group/events/273683462/",
+		"UID:london@techmeetup",
+		"DTSTART;TZID=Europe/London:20201105T180000",
+		"STATUS:CANCELLED",
+		"TZID:Europe/London",
+		"BEGIN:DAYLIGHT",
+		"TZOFFSETTO:+0100",
+		"TZOFFSETTO:+0800",
+	}
+	for _, want := range wantLines {
+		if !strings.Contains(unfolded, want+"\r\n") {
+			t.Errorf("EventStore.ICalendar() missing line %q in\n%v", want, unfolded)
+		}
+	}
+	for _, unwanted := range []string{"Private session", "Untracked session"} {
+		if strings.Contains(unfolded, unwanted) {
+			t.Errorf("EventStore.ICalendar() contains %q which is not public or tracked", unwanted)
+		}
+	}
+	if strings.Index(unfolded, "UID:webinar-78") > strings.Index(unfolded, "UID:london") {
+		t.Errorf("EventStore.ICalendar() events are not ordered by start date")
+	}
+}
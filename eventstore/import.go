@@ -0,0 +1,88 @@
+package eventstore
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/eventmgmt"
+	"github.com/hairizuanbinnoorazman/techmeetup/streaming"
+)
+
+// ImportEvents builds eventstore events from the events on meetup and the broadcasts on
+// streamyard. Meetup events and broadcasts with the same title and start time are joined
+// into a single event. Imported events are not tracked so that nothing is changed on the
+// platforms until the events are reviewed
+func ImportEvents(meetupEvents []eventmgmt.Event, streams []streaming.Stream, loc *time.Location) []Event {
+	if loc == nil {
+		loc = time.UTC
+	}
+	events := []Event{}
+	joined := map[string]bool{}
+	for _, me := range meetupEvents {
+		e := Event{
+			MeetupID:  me.ID,
+			Title:     strings.TrimSpace(me.Name),
+			StartDate: me.StartTime.In(loc),
+			Duration:  me.Duration,
+			IsOnline:  me.IsWebinar,
+		}
+		if me.Status == "cancelled" {
+			e.Status = StatusCancelled
+		}
+		st, found, _ := matchStream(streams, e)
+		if found {
+			joined[st.ID] = true
+			e = withStream(e, st)
+		}
+		if e.YoutubeLink == "" && strings.Contains(me.WebinarLink, "youtu") {
+			e.YoutubeLink = me.WebinarLink
+		}
+		desc := eventmgmt.ConvertMeetupHTMLToText(me.Description)
+		// Youtube links are added to meetup descriptions by the sync and are removed so that
+		// they are not added twice
+		if e.YoutubeLink != "" {
+			desc = strings.TrimSuffix(desc, eventmgmt.AppendYoutubeLinktoDesc("", e.YoutubeLink))
+		}
+		if strings.TrimSpace(desc) != "" {
+			e.Description = strings.TrimSpace(desc)
+		}
+		e.ID = GenerateEventID(e)
+		events = append(events, e)
+	}
+	for _, st := range streams {
+		if joined[st.ID] {
+			continue
+		}
+		e := withStream(Event{
+			Title:       strings.TrimSpace(st.Name),
+			StartDate:   st.StartDate.In(loc),
+			Description: st.Description,
+		}, st)
+		e.ID = GenerateEventID(e)
+		events = append(events, e)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].StartDate.Before(events[j].StartDate)
+	})
+	return events
+}
+
+// withStream fills in the streamyard details of the event
+func withStream(e Event, st streaming.Stream) Event {
+	e.StreamyardID = st.ID
+	e.IsOnline = true
+	e.IsPublic = st.IsPublic
+	for _, dest := range st.Destinations {
+		switch dest.Type {
+		case "youtube":
+			e.YoutubeLink = dest.Link
+		case "facebook":
+			e.FacebookLink = dest.Link
+		}
+	}
+	if e.Description == "" {
+		e.Description = st.Description
+	}
+	return e
+}
@@ -0,0 +1,103 @@
+package eventstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/eventmgmt"
+	"github.com/hairizuanbinnoorazman/techmeetup/streaming"
+)
+
+func TestImportEvents(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Singapore")
+	startDate := time.Date(2099, 10, 15, 19, 30, 0, 0, loc)
+	tests := []struct {
+		name         string
+		meetupEvents []eventmgmt.Event
+		streams      []streaming.Stream
+		want         []Event
+	}{
+		{
+			name: "Meetup event joined with broadcast",
+			meetupEvents: []eventmgmt.Event{{
+				ID:          "meetup-1",
+				Name:        "Webinar 78 - Observability",
+				Description: "<p>Some description\nYou can watch the live video via the following link:\nhttps://youtu.be/abc</p>",
+				StartTime:   startDate.UTC(),
+				IsWebinar:   true,
+				Duration:    90,
+			}},
+			streams: []streaming.Stream{{
+				ID:           "stream-1",
+				Name:         "Webinar 78 - Observability",
+				StartDate:    startDate.UTC(),
+				IsPublic:     true,
+				Destinations: []streaming.Destination{{Type: "youtube", Link: "https://youtu.be/abc"}},
+			}},
+			want: []Event{{
+				ID:           "20991015-webinar-78-observability",
+				MeetupID:     "meetup-1",
+				StreamyardID: "stream-1",
+				Title:        "Webinar 78 - Observability",
+				Description:  "Some description",
+				StartDate:    startDate,
+				Duration:     90,
+				IsOnline:     true,
+				IsPublic:     true,
+				YoutubeLink:  "https://youtu.be/abc",
+			}},
+		},
+		{
+			name: "Unjoined events are sorted by start date",
+			meetupEvents: []eventmgmt.Event{{
+				ID:        "meetup-2",
+				Name:      "Webinar 79",
+				StartTime: startDate.AddDate(0, 0, 14),
+				Status:    "cancelled",
+			}},
+			streams: []streaming.Stream{{
+				ID:          "stream-1",
+				Name:        "Webinar 78",
+				Description: "Stream description",
+				StartDate:   startDate,
+			}},
+			want: []Event{
+				{
+					ID:           "20991015-webinar-78",
+					StreamyardID: "stream-1",
+					Title:        "Webinar 78",
+					Description:  "Stream description",
+					StartDate:    startDate,
+					IsOnline:     true,
+				},
+				{
+					ID:        "20991029-webinar-79",
+					MeetupID:  "meetup-2",
+					Status:    StatusCancelled,
+					Title:     "Webinar 79",
+					StartDate: startDate.AddDate(0, 0, 14),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ImportEvents(tt.meetupEvents, tt.streams, loc)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ImportEvents() = %v events, want %v", len(got), len(tt.want))
+			}
+			for idx := range got {
+				g, w := got[idx], tt.want[idx]
+				if !g.StartDate.Equal(w.StartDate) || g.StartDate.Location().String() != loc.String() {
+					t.Errorf("ImportEvents() start date = %v, want %v", g.StartDate, w.StartDate)
+				}
+				g.StartDate, w.StartDate = time.Time{}, time.Time{}
+				if g.ID != w.ID || g.MeetupID != w.MeetupID || g.StreamyardID != w.StreamyardID || g.Title != w.Title ||
+					g.Description != w.Description || g.Status != w.Status || g.Duration != w.Duration ||
+					g.IsOnline != w.IsOnline || g.IsPublic != w.IsPublic || g.YoutubeLink != w.YoutubeLink || g.TrackEvent {
+					t.Errorf("ImportEvents() = %+v, want %+v", g, w)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package eventstore
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Action is the kind of change that would be made on a platform for an event
+type Action string
+
+const (
+	ActionNoop   Action = "no-op"
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionSkip   Action = "skip"
+	ActionCancel Action = "cancel"
+	ActionDelete Action = "delete"
+	// ActionAdopt writes the ID of an existing event on the platform back to the eventstore
+	ActionAdopt Action = "adopt"
+)
+
+// knownAfterApply is used as a placeholder for values that would only be available
+// once a change is applied - e.g. the youtube link after a streamyard stream is created
+const knownAfterApply = "(known after apply)"
+
+type FieldDiff struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Change is the planned (and if applied, the outcome of the) change on a single
+// platform for a single event. Frozen changes are reported but not applied as the
+// event is within its freeze window
+type Change struct {
+	EventID  string      `json:"event_id"`
+	Title    string      `json:"title"`
+	Platform string      `json:"platform"`
+	Action   Action      `json:"action"`
+	Reason   string      `json:"reason,omitempty"`
+	Diffs    []FieldDiff `json:"diffs,omitempty"`
+	Frozen   bool        `json:"frozen"`
+	Applied  bool        `json:"applied"`
+	Error    string      `json:"error,omitempty"`
+	// payload holds all values that the platform is expected to have, including those
+	// that are unchanged
+	payload map[string]string
+}
+
+func newChange(e Event, platform string) Change {
+	return Change{
+		EventID:  e.ID,
+		Title:    e.Title,
+		Platform: platform,
+		Action:   ActionNoop,
+	}
+}
+
+func (c Change) skip(reason string) Change {
+	c.Action = ActionSkip
+	c.Reason = reason
+	return c
+}
+
+func (c Change) fail(err error) Change {
+	c.Action = ActionSkip
+	c.Error = err.Error()
+	return c
+}
+
+// diff records a field level change if before and after differ
+func (c *Change) diff(field, before, after string) {
+	if c.payload == nil {
+		c.payload = map[string]string{}
+	}
+	c.payload[field] = after
+	if before == after {
+		return
+	}
+	c.Diffs = append(c.Diffs, FieldDiff{Field: field, Before: before, After: after})
+}
+
+func (c Change) hasDiff(field string) bool {
+	for _, d := range c.Diffs {
+		if d.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Mutates is true when applying the change would alter a platform, or in the case of adoptions,
+// the platform IDs of the event
+func (c Change) Mutates() bool {
+	return c.Action == ActionCreate || c.Action == ActionUpdate || c.Action == ActionAdopt || c.Removes()
+}
+
+// Removes is true when applying the change would cancel or delete the event on a platform
+func (c Change) Removes() bool {
+	return c.Action == ActionCancel || c.Action == ActionDelete
+}
+
+// Plan holds all the changes computed in a single run through the eventstore
+type Plan struct {
+	Changes []Change `json:"changes"`
+}
+
+// approves is true if the plan has the same change for the event and platform. Values that were
+// only known after apply when the plan was computed match any value
+func (p Plan) approves(c Change) bool {
+	for _, a := range p.Changes {
+		if a.EventID != c.EventID || a.Platform != c.Platform {
+			continue
+		}
+		if a.Action != c.Action || a.Frozen || len(a.Diffs) != len(c.Diffs) {
+			return false
+		}
+		for idx, d := range a.Diffs {
+			got := c.Diffs[idx]
+			if d.Field != got.Field || !approvedValue(d.Before, got.Before) || !approvedValue(d.After, got.After) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func approvedValue(approved, value string) bool {
+	return approved == value || strings.Contains(approved, knownAfterApply)
+}
+
+// HasChanges is true if there are changes that would be applied. Frozen changes are excluded
+func (p Plan) HasChanges() bool {
+	for _, c := range p.Changes {
+		if c.Mutates() && !c.Frozen {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the plan for human consumption, similar to what terraform outputs
+func (p Plan) String() string {
+	var b strings.Builder
+	lastEvent := ""
+	counts := map[Action]int{}
+	for _, c := range p.Changes {
+		if c.EventID != lastEvent {
+			fmt.Fprintf(&b, "Event: %v (%v)\n", c.Title, c.EventID)
+			lastEvent = c.EventID
+		}
+		counts[c.Action]++
+		fmt.Fprintf(&b, "  %v %v: %v", actionSymbol(c.Action), c.Platform, c.Action)
+		if c.Reason != "" {
+			fmt.Fprintf(&b, " (%v)", c.Reason)
+		}
+		if c.Error != "" {
+			fmt.Fprintf(&b, " [error: %v]", c.Error)
+		} else if c.Frozen {
+			fmt.Fprint(&b, " [frozen]")
+		} else if c.Applied {
+			fmt.Fprint(&b, " [applied]")
+		}
+		fmt.Fprint(&b, "\n")
+		for _, d := range c.Diffs {
+			fmt.Fprintf(&b, "      %v: %q => %q\n", d.Field, d.Before, d.After)
+		}
+	}
+	fmt.Fprintf(&b, "Plan: %v to create, %v to adopt, %v to update, %v to cancel, %v unchanged, %v skipped\n", counts[ActionCreate], counts[ActionAdopt], counts[ActionUpdate], counts[ActionCancel]+counts[ActionDelete], counts[ActionNoop], counts[ActionSkip])
+	return b.String()
+}
+
+func actionSymbol(a Action) string {
+	switch a {
+	case ActionCreate:
+		return "+"
+	case ActionUpdate:
+		return "~"
+	case ActionAdopt:
+		return "="
+	case ActionCancel, ActionDelete:
+		return "-"
+	case ActionSkip:
+		return "!"
+	default:
+		return " "
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatBool(b bool) string {
+	return fmt.Sprintf("%v", b)
+}
+
+func formatInt(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%v", i)
+}
@@ -0,0 +1,356 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/eventmgmt"
+	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+)
+
+func TestPlan_String(t *testing.T) {
+	tests := []struct {
+		name         string
+		plan         Plan
+		wantContains []string
+	}{
+		{
+			name: "Create and update",
+			plan: Plan{Changes: []Change{
+				{EventID: "a", Title: "Webinar A", Platform: "streamyard", Action: ActionCreate, Diffs: []FieldDiff{{Field: "title", After: "Webinar A"}}},
+				{EventID: "a", Title: "Webinar A", Platform: "meetup", Action: ActionUpdate, Diffs: []FieldDiff{{Field: "title", Before: "Old", After: "Webinar A"}}},
+				{EventID: "a", Title: "Webinar A", Platform: "calendar", Action: ActionSkip, Reason: "Calendar sync is disabled"},
+			}},
+			wantContains: []string{
+				"Event: Webinar A (a)\n",
+				"  + streamyard: create\n",
+				"      title: \"Old\" => \"Webinar A\"\n",
+				"  ! calendar: skip (Calendar sync is disabled)\n",
+				"Plan: 1 to create, 0 to adopt, 1 to update, 0 to cancel, 0 unchanged, 1 skipped\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.plan.String()
+			for _, w := range tt.wantContains {
+				if !strings.Contains(got, w) {
+					t.Errorf("Plan.String() = %v, want to contain %v", got, w)
+				}
+			}
+		})
+	}
+}
+
+func TestEventStore_Plan(t *testing.T) {
+	tests := []struct {
+		name           string
+		featureControl SubMeetupFeatureControl
+		wantActions    []Action
+		wantChanges    bool
+	}{
+		{
+			name:           "All syncs disabled",
+			featureControl: SubMeetupFeatureControl{},
+			wantActions:    []Action{ActionSkip, ActionSkip, ActionSkip, ActionSkip, ActionSkip, ActionSkip, ActionSkip},
+		},
+		{
+			name:           "New event plans create on all platforms",
+			featureControl: SubMeetupFeatureControl{StreamyardSync: true, MeetupSync: true, CalendarSync: true},
+			wantActions:    []Action{ActionSkip, ActionCreate, ActionCreate, ActionCreate, ActionSkip, ActionSkip, ActionSkip},
+			wantChanges:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := yamlStoreHelper(t, fmt.Sprintf(`- track_event: true
+  start_date: "2099-10-15T19:30:00+08:00"
+  title: Webinar 78 - Observability
+  description: Some description
+  featured_image_path: %v
+  is_online: true
+  duration: 90
+  organizers:
+  - name: Organizer
+    email: organizer@example.com
+- track_event: false
+  start_date: "2099-10-29T19:30:00+08:00"
+  title: Untracked
+`, imageHelper(t)))
+			s := EventStore{
+				store:               store,
+				logger:              logger.LoggerForTests{Tester: t},
+				calendarEventInvite: "Join via %v",
+				featureControl:      tt.featureControl,
+			}
+			s.streamyardSvc, s.meetupClient = platformClientsHelper(t, nil, nil, nil)
+			got, err := s.Plan(context.TODO())
+			if err != nil {
+				t.Fatalf("EventStore.Plan() error = %v", err)
+			}
+			if len(got.Changes) != len(tt.wantActions) {
+				t.Fatalf("EventStore.Plan() = %v changes, want %v", len(got.Changes), len(tt.wantActions))
+			}
+			for idx, c := range got.Changes {
+				if c.Action != tt.wantActions[idx] {
+					t.Errorf("EventStore.Plan() %v action = %v, want %v", c.Platform, c.Action, tt.wantActions[idx])
+				}
+			}
+			if got.HasChanges() != tt.wantChanges {
+				t.Errorf("Plan.HasChanges() = %v, want %v", got.HasChanges(), tt.wantChanges)
+			}
+		})
+	}
+}
+
+func TestEventStore_Plan_MeetupUpdate(t *testing.T) {
+	startDate := time.Date(2099, 10, 15, 19, 30, 0, 0, time.FixedZone("SGT", 8*60*60))
+	tests := []struct {
+		name       string
+		status     string
+		link       string
+		wantFields []string
+	}{
+		{
+			name:   "No drift",
+			status: "upcoming",
+			link:   "https://youtu.be/abc",
+		},
+		{
+			name:       "Draft event is published",
+			status:     "draft",
+			link:       "https://youtu.be/abc",
+			wantFields: []string{"is_public"},
+		},
+		{
+			name:       "Webinar link is updated",
+			status:     "upcoming",
+			link:       "https://youtu.be/old",
+			wantFields: []string{"webinar_link"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := yamlStoreHelper(t, fmt.Sprintf(`- track_event: true
+  start_date: "2099-10-15T19:30:00+08:00"
+  title: Webinar 78 - Observability
+  description: Some description
+  featured_image_path: %v
+  is_online: true
+  is_public: true
+  meetup_id: meetup-1
+  streamyard_id: stream-1
+  youtube_link: https://youtu.be/abc
+  duration: 90
+  organizers:
+  - name: Organizer
+    email: organizer@example.com
+`, imageHelper(t)))
+			s := EventStore{
+				store:          store,
+				logger:         logger.LoggerForTests{Tester: t},
+				featureControl: SubMeetupFeatureControl{MeetupSync: true},
+			}
+			s.streamyardSvc, s.meetupClient = platformClientsHelper(t, nil, []eventmgmt.MeetupEventResp{{
+				ID:          "meetup-1",
+				Name:        "Webinar 78 - Observability",
+				Description: eventmgmt.AppendYoutubeLinktoDesc("Some description", "https://youtu.be/abc"),
+				Status:      tt.status,
+				Time:        startDate.Unix() * 1000,
+				HowToFindUs: tt.link,
+			}}, nil)
+			got, err := s.Sync(context.TODO(), SyncOptions{DryRun: true, Platform: "meetup"})
+			if err != nil {
+				t.Fatalf("EventStore.Sync() error = %v", err)
+			}
+			if len(got.Changes) != 1 {
+				t.Fatalf("EventStore.Sync() = %v changes, want 1", len(got.Changes))
+			}
+			if got.Changes[0].Error != "" || got.Changes[0].Action == ActionSkip {
+				t.Fatalf("EventStore.Sync() = %+v, want the meetup event to be compared", got.Changes[0])
+			}
+			var fields []string
+			for _, d := range got.Changes[0].Diffs {
+				fields = append(fields, d.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("EventStore.Sync() diffs = %v, want %v. Change: %+v", fields, tt.wantFields, got.Changes[0])
+			}
+		})
+	}
+}
+
+func TestEventStore_Plan_Cancelled(t *testing.T) {
+	store := yamlStoreHelper(t, fmt.Sprintf(`- track_event: true
+  status: cancelled
+  start_date: "2099-10-15T19:30:00+08:00"
+  title: Webinar 78 - Observability
+  description: Some description
+  featured_image_path: %v
+  is_online: true
+  streamyard_id: abc
+  youtube_link: https://youtube.com/abc
+  duration: 90
+  organizers:
+  - name: Organizer
+    email: organizer@example.com
+`, imageHelper(t)))
+	s := EventStore{
+		store:          store,
+		logger:         logger.LoggerForTests{Tester: t},
+		featureControl: SubMeetupFeatureControl{StreamyardSync: true, MeetupSync: true, CalendarSync: true},
+		freezeWindow:   &FreezeWindow{Before: 1000000 * time.Hour},
+	}
+	got, err := s.Plan(context.TODO())
+	if err != nil {
+		t.Fatalf("EventStore.Plan() error = %v", err)
+	}
+	wantActions := []Action{ActionSkip, ActionDelete, ActionNoop, ActionNoop, ActionSkip, ActionSkip, ActionSkip}
+	if len(got.Changes) != len(wantActions) {
+		t.Fatalf("EventStore.Plan() = %v changes, want %v", len(got.Changes), len(wantActions))
+	}
+	for idx, c := range got.Changes {
+		if c.Action != wantActions[idx] {
+			t.Errorf("EventStore.Plan() %v action = %v, want %v", c.Platform, c.Action, wantActions[idx])
+		}
+		if c.Frozen {
+			t.Errorf("EventStore.Plan() %v is frozen, cancellations should not be frozen", c.Platform)
+		}
+	}
+	if !got.HasChanges() {
+		t.Errorf("Plan.HasChanges() = false, want true")
+	}
+}
+
+func TestEventStore_Sync_Concurrent(t *testing.T) {
+	content := ""
+	wantIDs := []string{}
+	for i := 1; i <= 9; i++ {
+		content += fmt.Sprintf(`- id: event-%v
+  track_event: %v
+  start_date: "2099-10-%02dT19:30:00+08:00"
+  title: Webinar %v - Observability
+  description: Some description
+  duration: 90
+  organizers:
+  - name: Organizer
+    email: organizer@example.com
+`, i, i%3 != 0, i, i)
+		if i%3 != 0 {
+			wantIDs = append(wantIDs, fmt.Sprintf("event-%v", i))
+		}
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantError bool
+	}{
+		{
+			name: "Order of events is kept",
+			ctx:  context.Background(),
+		},
+		{
+			name:      "Steps are not run after the context is done",
+			ctx:       cancelled,
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := EventStore{
+				store:   yamlStoreHelper(t, content),
+				logger:  logger.LoggerForTests{Tester: t},
+				workers: 4,
+			}
+			got, err := s.Sync(tt.ctx, SyncOptions{DryRun: true})
+			if err != nil {
+				t.Fatalf("EventStore.Sync() error = %v", err)
+			}
+			gotIDs := []string{}
+			for _, c := range got.Changes {
+				if (c.Error != "") != tt.wantError {
+					t.Errorf("EventStore.Sync() %v %v error = %v, wantError %v", c.EventID, c.Platform, c.Error, tt.wantError)
+				}
+				if len(gotIDs) == 0 || gotIDs[len(gotIDs)-1] != c.EventID {
+					gotIDs = append(gotIDs, c.EventID)
+				}
+			}
+			if strings.Join(gotIDs, ",") != strings.Join(wantIDs, ",") {
+				t.Errorf("EventStore.Sync() event order = %v, want %v", gotIDs, wantIDs)
+			}
+		})
+	}
+}
+
+func TestEventStore_Apply_Approved(t *testing.T) {
+	startDate := time.Now().AddDate(0, 1, 0).Truncate(time.Hour).UTC()
+	event := `track_event: true
+start_date: "` + startDate.Format(time.RFC3339) + `"
+title: %v
+description: Some description
+duration: 90
+is_online: true
+organizers:
+- name: Organizer
+  email: organizer@example.com
+`
+	tests := []struct {
+		name        string
+		editedTitle string
+		wantApplied bool
+	}{
+		{
+			name:        "Approved changes are applied",
+			editedTitle: "Meetup 20 - Observability",
+			wantApplied: true,
+		},
+		{
+			name:        "Changes that differ from the approved plan are not applied",
+			editedTitle: "Meetup 20 - Tracing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := dirStoreHelper(t, map[string]string{"meetup-20.yaml": fmt.Sprintf(event, "Meetup 20 - Observability")})
+			svc, fake := slidesServerHelper(t, map[string]string{"title": "{{title}}"})
+			s := EventStore{
+				store:          store,
+				logger:         logger.LoggerForTests{Tester: t},
+				featureControl: SubMeetupFeatureControl{SlidesSync: true},
+				location:       time.UTC,
+			}
+			WithSlides(svc, "template-id", "")(&s)
+
+			approved, err := s.Plan(context.TODO())
+			if err != nil {
+				t.Fatalf("EventStore.Plan() error = %v", err)
+			}
+			e, _ := store.Get("meetup-20")
+			e.Title = tt.editedTitle
+			store.Put(e)
+
+			got, err := s.Apply(context.TODO(), &approved)
+			if err != nil {
+				t.Fatalf("EventStore.Apply() error = %v", err)
+			}
+			for _, c := range got.Changes {
+				if c.Platform != "slides" {
+					continue
+				}
+				if c.Applied != tt.wantApplied || (c.Error == "") != tt.wantApplied {
+					t.Errorf("EventStore.Apply() applied = %v (%v), want %v", c.Applied, c.Error, tt.wantApplied)
+				}
+			}
+			if (fake.updates > 0) != tt.wantApplied {
+				t.Errorf("EventStore.Apply() = %v slides updates, want applied %v", fake.updates, tt.wantApplied)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package eventstore
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+)
+
+const seriesDateFormat = "2006-01-02"
+
+// Series is a recurring set of events, e.g. a webinar every other Thursday at 19:30.
+// Placeholder events are created in the eventstore for the next WeeksAhead weeks so that
+// the events are available on the platforms well in advance. Organizers are then able
+// to fill in the details of the placeholder events in the eventstore
+type Series struct {
+	ID string `yaml:"id"`
+	// TitlePrefix is the series name used in the titles of the events, e.g. Webinar
+	TitlePrefix string `yaml:"title_prefix"`
+	// FirstDate is the date of the first event of the series in 2006-01-02 format
+	FirstDate string `yaml:"first_date"`
+	// Time is the start time of the events in 15:04 format
+	Time string `yaml:"time"`
+	// IntervalWeeks is the number of weeks between events. Defaults to every week
+	IntervalWeeks int `yaml:"interval_weeks"`
+	// WeeksAhead is how far ahead events are created
+	WeeksAhead int    `yaml:"weeks_ahead"`
+	TimeZone   string `yaml:"timezone"`
+	// In minutes
+	Duration int `yaml:"duration"`
+	// Description is a go template. The event's StartDate and the Series are available in it
+	Description         string            `yaml:"description"`
+	Organizers          []Organizer       `yaml:"organizers"`
+	IsOnline            bool              `yaml:"is_online"`
+	IsPublic            bool              `yaml:"is_public"`
+	GenerateBannerImage bool              `yaml:"generate_banner_image"`
+	SkipDates           []string          `yaml:"skip_dates"`
+	Exceptions          []SeriesException `yaml:"exceptions"`
+}
+
+// SeriesException alters a single occurrence of the series
+type SeriesException struct {
+	// Date is the original date of the occurrence in 2006-01-02 format
+	Date string `yaml:"date"`
+	// MoveTo moves the occurrence to another date in 2006-01-02 format
+	MoveTo string `yaml:"move_to"`
+	// Time overrides the start time of the occurrence in 15:04 format
+	Time  string `yaml:"time"`
+	Title string `yaml:"title"`
+}
+
+// WithSeries sets the recurring series from which events are created
+func WithSeries(series []Series) Option {
+	return func(s *EventStore) {
+		s.series = series
+	}
+}
+
+func (r Series) location(defaultLoc *time.Location) (*time.Location, error) {
+	if r.TimeZone == "" {
+		if defaultLoc == nil {
+			return time.UTC, nil
+		}
+		return defaultLoc, nil
+	}
+	loc, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load timezone for series %v. Err: %v", r.ID, err)
+	}
+	return loc, nil
+}
+
+// Occurrences returns the dates of the events in the series from now till WeeksAhead weeks later.
+// Skip dates are excluded. Exceptions are not applied
+func (r Series) Occurrences(now time.Time, loc *time.Location) ([]time.Time, error) {
+	first, err := time.ParseInLocation(seriesDateFormat+" 15:04", r.FirstDate+" "+r.Time, loc)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse first date and time of series %v. Err: %v", r.ID, err)
+	}
+	interval := r.IntervalWeeks
+	if interval <= 0 {
+		interval = 1
+	}
+	skip := map[string]bool{}
+	for _, d := range r.SkipDates {
+		skip[d] = true
+	}
+
+	until := now.AddDate(0, 0, 7*r.WeeksAhead)
+	occurrences := []time.Time{}
+	// AddDate keeps the wall clock time of the events the same across daylight saving changes
+	for i := 0; ; i++ {
+		t := first.AddDate(0, 0, 7*interval*i)
+		if t.After(until) {
+			break
+		}
+		if t.Before(now) || skip[t.Format(seriesDateFormat)] {
+			continue
+		}
+		occurrences = append(occurrences, t)
+	}
+	return occurrences, nil
+}
+
+// Events returns the placeholder events of the series from now till WeeksAhead weeks later
+func (r Series) Events(now time.Time, defaultLoc *time.Location) ([]Event, error) {
+	loc, err := r.location(defaultLoc)
+	if err != nil {
+		return nil, err
+	}
+	occurrences, err := r.Occurrences(now, loc)
+	if err != nil {
+		return nil, err
+	}
+	exceptions := map[string]SeriesException{}
+	for _, ex := range r.Exceptions {
+		exceptions[ex.Date] = ex
+	}
+	descTemplate, err := template.New(r.ID).Parse(r.Description)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse description template of series %v. Err: %v", r.ID, err)
+	}
+
+	events := []Event{}
+	for _, t := range occurrences {
+		seriesDate := t.Format(seriesDateFormat)
+		startDate := t
+		title := fmt.Sprintf("%v - %v", r.TitlePrefix, t.Format("2 January 2006"))
+		if ex, ok := exceptions[seriesDate]; ok {
+			date := seriesDate
+			if ex.MoveTo != "" {
+				date = ex.MoveTo
+			}
+			clock := r.Time
+			if ex.Time != "" {
+				clock = ex.Time
+			}
+			startDate, err = time.ParseInLocation(seriesDateFormat+" 15:04", date+" "+clock, loc)
+			if err != nil {
+				return nil, fmt.Errorf("Unable to parse exception of series %v on %v. Err: %v", r.ID, seriesDate, err)
+			}
+			if ex.Title != "" {
+				title = ex.Title
+			}
+		}
+
+		var desc bytes.Buffer
+		err = descTemplate.Execute(&desc, struct {
+			Series    Series
+			StartDate time.Time
+		}{Series: r, StartDate: startDate})
+		if err != nil {
+			return nil, fmt.Errorf("Unable to render description of series %v. Err: %v", r.ID, err)
+		}
+
+		e := Event{
+			TrackEvent:          true,
+			GenerateBannerImage: r.GenerateBannerImage,
+			StartDate:           startDate,
+			Title:               title,
+			Description:         desc.String(),
+			IsOnline:            r.IsOnline,
+			IsPublic:            r.IsPublic,
+			Organizers:          r.Organizers,
+			Duration:            r.Duration,
+			TimeZone:            r.TimeZone,
+			SeriesID:            r.ID,
+			SeriesDate:          seriesDate,
+		}
+		e.ID = GenerateEventID(e)
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// seriesEvents returns the placeholder events of all series that are not in the eventstore yet.
+// Existing events are matched on the series and the original date of the occurrence so that
+// placeholders that were renamed or rescheduled by organizers are not created again
+func (s EventStore) seriesEvents(existing []Event, now time.Time) ([]Event, error) {
+	known := map[string]bool{}
+	for _, e := range existing {
+		if e.SeriesID != "" {
+			known[e.SeriesID+"/"+e.SeriesDate] = true
+		}
+	}
+	events := []Event{}
+	for _, r := range s.series {
+		generated, err := r.Events(now, s.location)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range generated {
+			if known[e.SeriesID+"/"+e.SeriesDate] {
+				continue
+			}
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
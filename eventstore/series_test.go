@@ -0,0 +1,78 @@
+package eventstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeries_Events(t *testing.T) {
+	sgt, _ := time.LoadLocation("Asia/Singapore")
+	series := Series{
+		ID:            "webinar",
+		TitlePrefix:   "Webinar",
+		FirstDate:     "2020-10-01",
+		Time:          "19:30",
+		IntervalWeeks: 2,
+		WeeksAhead:    6,
+		Duration:      90,
+		Description:   "Webinar on {{ .StartDate.Format \"2 Jan\" }}",
+		SkipDates:     []string{"2020-10-29"},
+		Exceptions:    []SeriesException{{Date: "2020-11-12", MoveTo: "2020-11-13", Time: "20:00", Title: "Webinar - Special"}},
+	}
+	tests := []struct {
+		name      string
+		now       time.Time
+		wantDates []time.Time
+		wantTitle []string
+	}{
+		{
+			name: "Every other week with skip date and exception",
+			now:  time.Date(2020, 10, 10, 0, 0, 0, 0, sgt),
+			wantDates: []time.Time{
+				time.Date(2020, 10, 15, 19, 30, 0, 0, sgt),
+				time.Date(2020, 11, 13, 20, 0, 0, 0, sgt),
+			},
+			wantTitle: []string{"Webinar - 15 October 2020", "Webinar - Special"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := series.Events(tt.now, sgt)
+			if err != nil {
+				t.Fatalf("Series.Events() error = %v", err)
+			}
+			if len(got) != len(tt.wantDates) {
+				t.Fatalf("Series.Events() = %v events, want %v", len(got), len(tt.wantDates))
+			}
+			for idx, e := range got {
+				if !e.StartDate.Equal(tt.wantDates[idx]) {
+					t.Errorf("Series.Events() start date = %v, want %v", e.StartDate, tt.wantDates[idx])
+				}
+				if e.Title != tt.wantTitle[idx] {
+					t.Errorf("Series.Events() title = %v, want %v", e.Title, tt.wantTitle[idx])
+				}
+				if e.SeriesID != "webinar" || e.ID == "" || !e.TrackEvent {
+					t.Errorf("Series.Events() = %+v, want series id, id and tracked", e)
+				}
+			}
+			if got[0].Description != "Webinar on 15 Oct" {
+				t.Errorf("Series.Events() description = %v, want Webinar on 15 Oct", got[0].Description)
+			}
+		})
+	}
+}
+
+func TestEventStore_seriesEvents(t *testing.T) {
+	s := EventStore{
+		location: time.UTC,
+		series:   []Series{{ID: "webinar", TitlePrefix: "Webinar", FirstDate: "2020-10-01", Time: "19:30", WeeksAhead: 2}},
+	}
+	existing := []Event{{Title: "Renamed webinar", SeriesID: "webinar", SeriesDate: "2020-10-08"}}
+	got, err := s.seriesEvents(existing, time.Date(2020, 10, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("EventStore.seriesEvents() error = %v", err)
+	}
+	if len(got) != 1 || got[0].SeriesDate != "2020-10-15" {
+		t.Errorf("EventStore.seriesEvents() = %+v, want only the event on 2020-10-15", got)
+	}
+}
@@ -0,0 +1,296 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/sheets"
+	"github.com/hairizuanbinnoorazman/techmeetup/youtube"
+)
+
+// sheetsColumns is the layout of the spreadsheet that is maintained by the sheets reporter.
+// Rows are matched to events by the event ID in the first column
+var sheetsColumns = []struct {
+	field  string
+	header string
+}{
+	{field: "event_id", header: "Event ID"},
+	{field: "date", header: "Date"},
+	{field: "title", header: "Title"},
+	{field: "speakers", header: "Speakers"},
+	{field: "meetup_link", header: "Meetup Link"},
+	{field: "youtube_link", header: "Youtube Link"},
+	{field: "rsvp_count", header: "RSVPs"},
+	{field: "view_count", header: "Views"},
+	{field: "sync_status", header: "Sync Status"},
+}
+
+// sheetsStatsWindow is how long after the start of an event its RSVP and view counts are still
+// retrieved. Counts of older events are kept as they are in the spreadsheet so that each sync
+// only looks up the stats of a handful of events
+const sheetsStatsWindow = 30 * 24 * time.Hour
+
+// sheetsReporter keeps a row per event in a spreadsheet so that the wider organizing team has
+// an overview of all events. The spreadsheet is read once per sync and the rows are kept up to
+// date as they are written. Events are synced concurrently, so rows are accessed one at a time
+type sheetsReporter struct {
+	sheetsSvc     sheets.GoogleSheets
+	spreadsheetID string
+	mu            sync.Mutex
+	// rows are the rows of the first sheet of the spreadsheet, including the header row. Nil
+	// until the spreadsheet is read
+	rows [][]string
+}
+
+// WithSheetsReporter sets the spreadsheet that the sheets reporter maintains. The sheets
+// reporter is not run if the spreadsheet ID is empty
+func WithSheetsReporter(sheetsSvc sheets.GoogleSheets, spreadsheetID string) Option {
+	return func(s *EventStore) {
+		if spreadsheetID == "" {
+			return
+		}
+		s.sheetsReporter = &sheetsReporter{
+			sheetsSvc:     sheetsSvc,
+			spreadsheetID: spreadsheetID,
+		}
+	}
+}
+
+// forSync is the reporter for a new sync, which reads the spreadsheet again
+func (r *sheetsReporter) forSync() *sheetsReporter {
+	return &sheetsReporter{
+		sheetsSvc:     r.sheetsSvc,
+		spreadsheetID: r.spreadsheetID,
+	}
+}
+
+// load reads the spreadsheet unless it was already read. The lock needs to be held
+func (r *sheetsReporter) load(ctx context.Context) error {
+	if r.rows != nil {
+		return nil
+	}
+	rows, err := r.sheetsSvc.GetRows(ctx, r.spreadsheetID, "")
+	if err != nil {
+		return err
+	}
+	r.rows = rows
+	return nil
+}
+
+// row returns the row of the event. The row is empty if the event is not in the spreadsheet
+func (r *sheetsReporter) row(ctx context.Context, id string) ([]string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	err := r.load(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	idx := findSheetsRow(r.rows, id)
+	if idx < 0 {
+		return []string{}, false, nil
+	}
+	return r.rows[idx], true, nil
+}
+
+// put updates the row of the event in place or adds it if the event is not in the spreadsheet
+// yet. The header row is added to empty spreadsheets
+func (r *sheetsReporter) put(ctx context.Context, id string, values []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	err := r.load(ctx)
+	if err != nil {
+		return err
+	}
+	if len(r.rows) == 0 {
+		headers := []string{}
+		for _, col := range sheetsColumns {
+			headers = append(headers, col.header)
+		}
+		err = r.sheetsSvc.AppendRow(ctx, r.spreadsheetID, "", headers)
+		if err != nil {
+			return err
+		}
+		r.rows = append(r.rows, headers)
+	}
+	idx := findSheetsRow(r.rows, id)
+	if idx < 0 {
+		err = r.sheetsSvc.AppendRow(ctx, r.spreadsheetID, "", values)
+		if err != nil {
+			return err
+		}
+		r.rows = append(r.rows, values)
+		return nil
+	}
+	err = r.sheetsSvc.UpdateRow(ctx, r.spreadsheetID, "", idx+1, values)
+	if err != nil {
+		return err
+	}
+	r.rows[idx] = values
+	return nil
+}
+
+// findSheetsRow returns the index of the row of the event. The header row is never matched
+func findSheetsRow(rows [][]string, id string) int {
+	for idx, row := range rows {
+		if idx == 0 || len(row) == 0 {
+			continue
+		}
+		if row[0] == id {
+			return idx
+		}
+	}
+	return -1
+}
+
+// sheetsColumn is the index of the column of the field
+func sheetsColumn(field string) int {
+	for idx, col := range sheetsColumns {
+		if col.field == field {
+			return idx
+		}
+	}
+	return -1
+}
+
+func sheetsCell(row []string, idx int) string {
+	if idx < len(row) {
+		return row[idx]
+	}
+	return ""
+}
+
+func (s *EventStore) planSheets(ctx context.Context, e Event) Change {
+	c := newChange(e, "sheets")
+	if !s.featureControl.SheetsReporterSync {
+		return c.skip("Sheets reporter sync is disabled")
+	}
+
+	if s.sheetsReporter == nil {
+		return c.skip("Spreadsheet for event stats is not set in config")
+	}
+
+	current, found, err := s.sheetsReporter.row(ctx, e.ID)
+	if err != nil {
+		return c.fail(err)
+	}
+	expected, err := s.sheetsRow(ctx, e, current)
+	if err != nil {
+		return c.fail(err)
+	}
+	for i, col := range sheetsColumns {
+		c.diff(col.field, sheetsCell(current, i), expected[i])
+	}
+	switch {
+	case !found:
+		c.Action = ActionCreate
+	case len(c.Diffs) > 0:
+		c.Action = ActionUpdate
+	}
+	return c
+}
+
+func (s *EventStore) applySheets(ctx context.Context, e Event, c Change) (Event, error) {
+	values := []string{}
+	for _, col := range sheetsColumns {
+		values = append(values, c.payload[col.field])
+	}
+	s.logger.Infof("Updating row of event in spreadsheet. Event: %v", e.Title)
+	err := s.sheetsReporter.put(ctx, e.ID, values)
+	if err != nil {
+		return e, fmt.Errorf("Unable to update spreadsheet. Err: %v", err)
+	}
+	return e, nil
+}
+
+func expectedSheets(e Event) Event {
+	return e
+}
+
+// sheetsRow is the row of the event in the spreadsheet. RSVP and view counts are retrieved
+// from meetup and youtube until sheetsStatsWindow after the start of the event. After that,
+// the counts in the current row are kept and only retrieved if they are missing
+func (s *EventStore) sheetsRow(ctx context.Context, e Event, current []string) ([]string, error) {
+	e, err := s.withSpeakers(e)
+	if err != nil {
+		return nil, err
+	}
+	recent := time.Since(e.StartDate) < sheetsStatsWindow
+	meetupLink, rsvpCount := "", ""
+	switch {
+	case e.MeetupID == knownAfterApply:
+		meetupLink, rsvpCount = knownAfterApply, knownAfterApply
+	case e.MeetupID != "":
+		meetupLink = s.meetupClient.EventLink(e.MeetupID)
+		rsvpCount = sheetsCell(current, sheetsColumn("rsvp_count"))
+		if !recent && rsvpCount != "" {
+			break
+		}
+		meetupEvent, err := s.meetupClient.GetEvent(ctx, e.MeetupID)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to retrieve event details from meetup. Err: %v MeetupID: %v", err, e.MeetupID)
+		}
+		rsvpCount = strconv.Itoa(meetupEvent.RSVPCount)
+	}
+	viewCount := ""
+	switch {
+	case e.YoutubeLink == knownAfterApply:
+		viewCount = knownAfterApply
+	case e.YoutubeLink != "":
+		viewCount = sheetsCell(current, sheetsColumn("view_count"))
+		if !recent && viewCount != "" {
+			break
+		}
+		videoID, err := youtube.VideoID(e.YoutubeLink)
+		if err != nil {
+			return nil, err
+		}
+		videos, err := s.youtubeSvc.GetVideos(ctx, videoID)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to retrieve video from youtube. Err: %v VideoID: %v", err, videoID)
+		}
+		viewCount = ""
+		if len(videos) > 0 {
+			viewCount = strconv.FormatUint(videos[0].ViewCount, 10)
+		}
+	}
+	return []string{
+		e.ID,
+		e.StartDate.Format("2006-01-02 15:04 MST"),
+		e.Title,
+		speakerNames(e.Speakers()),
+		meetupLink,
+		e.YoutubeLink,
+		rsvpCount,
+		viewCount,
+		sheetsSyncStatus(e),
+	}, nil
+}
+
+// sheetsSyncStatus summarizes the status of the event and its last sync on each platform,
+// e.g. cancelled; calendar: ok; meetup: error
+func sheetsSyncStatus(e Event) string {
+	statuses := []string{}
+	if e.Status != StatusScheduled {
+		statuses = append(statuses, e.Status)
+	}
+	platforms := []string{}
+	for p := range e.SyncStatus {
+		if p != "sheets" {
+			platforms = append(platforms, p)
+		}
+	}
+	sort.Strings(platforms)
+	for _, p := range platforms {
+		result := "ok"
+		if e.SyncStatus[p].OutOfSync() {
+			result = "error"
+		}
+		statuses = append(statuses, fmt.Sprintf("%v: %v", p, result))
+	}
+	return strings.Join(statuses, "; ")
+}
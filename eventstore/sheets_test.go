@@ -0,0 +1,233 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+	"github.com/hairizuanbinnoorazman/techmeetup/sheets"
+	"google.golang.org/api/option"
+	sheetsapi "google.golang.org/api/sheets/v4"
+	youtubeapi "google.golang.org/api/youtube/v3"
+)
+
+// sheetsCalls records the calls to the fake spreadsheet
+type sheetsCalls struct {
+	// reads is the number of times the sheet was read
+	reads int
+	// cells are the single cells that were written, e.g. K2
+	cells []string
+}
+
+// sheetsServerHelper fakes the values api of a spreadsheet with a single sheet. Writes are kept
+// in rows. Calls are recorded in calls if it is not nil
+func sheetsServerHelper(t *testing.T, rows *[][]string, calls *sheetsCalls) sheets.GoogleSheets {
+	if calls == nil {
+		calls = &sheetsCalls{}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := ioutil.ReadAll(r.Body)
+		var body sheetsapi.ValueRange
+		json.Unmarshal(raw, &body)
+		values := []string{}
+		for _, row := range body.Values {
+			for _, v := range row {
+				values = append(values, sheetsFakeValue(v))
+			}
+		}
+		switch {
+		case r.Method == http.MethodGet:
+			calls.reads++
+			resp := sheetsapi.ValueRange{}
+			for _, row := range *rows {
+				cells := []interface{}{}
+				for _, v := range row {
+					cells = append(cells, v)
+				}
+				resp.Values = append(resp.Values, cells)
+			}
+			json.NewEncoder(w).Encode(resp)
+			return
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":append"):
+			*rows = append(*rows, values)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			var req sheetsapi.BatchUpdateValuesRequest
+			json.Unmarshal(raw, &req)
+			for _, data := range req.Data {
+				col := strings.IndexByte("ABCDEFGHIJKLMNOPQRSTUVWXYZ", data.Range[0])
+				idx, err := strconv.Atoi(data.Range[1:])
+				if col < 0 || err != nil || idx > len(*rows) {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				row := (*rows)[idx-1]
+				for len(row) <= col {
+					row = append(row, "")
+				}
+				row[col] = sheetsFakeValue(data.Values[0][0])
+				(*rows)[idx-1] = row
+				calls.cells = append(calls.cells, data.Range)
+			}
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":clear"):
+			cells := strings.TrimSuffix(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], ":clear")
+			idx, err := strconv.Atoi(strings.Split(cells, ":")[0])
+			if err != nil || idx > len(*rows) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			(*rows)[idx-1] = []string{}
+		case r.Method == http.MethodPut:
+			idx, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], "A"))
+			if err != nil || idx > len(*rows) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			(*rows)[idx-1] = values
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	t.Cleanup(srv.Close)
+	svc, err := sheetsapi.NewService(context.TODO(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("Unable to create sheets service. Err: %v", err)
+	}
+	return sheets.NewGoogleSheets(logger.LoggerForTests{Tester: t}, svc)
+}
+
+// sheetsFakeValue is the value as it is displayed in the sheet
+func sheetsFakeValue(v interface{}) string {
+	switch v {
+	case true:
+		return "TRUE"
+	case false:
+		return "FALSE"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func TestEventStore_Sync_Sheets(t *testing.T) {
+	header := []string{"Event ID", "Date", "Title", "Speakers", "Meetup Link", "Youtube Link", "RSVPs", "Views", "Sync Status"}
+	rows := [][]string{
+		header,
+		{"webinar-78", "2020-10-15 19:30 +08", "Old title"},
+	}
+	store := dirStoreHelper(t, map[string]string{
+		"webinar-78.yaml": `track_event: true
+is_online: true
+start_date: "2020-10-15T19:30:00+08:00"
+title: Webinar 78 - Observability
+description: Some description
+duration: 90
+youtube_link: https://youtu.be/abc123
+organizers:
+- name: Organizer
+  email: organizer@example.com
+agenda:
+- type: speaker
+  topic: Observability
+  speakers:
+  - name: Jane Doe
+  - name: John Doe
+sync_status:
+  meetup:
+    last_error: Unable to update meetup event
+  streamyard:
+    last_success: 2020-10-01T00:00:00Z
+`,
+		"webinar-80.yaml": `track_event: true
+is_online: true
+start_date: "` + time.Now().AddDate(0, 0, -2).Truncate(time.Minute).Format(time.RFC3339) + `"
+title: Webinar 80 - Tracing
+description: Some description
+duration: 90
+youtube_link: https://youtu.be/abc123
+organizers:
+- name: Organizer
+  email: organizer@example.com
+`,
+		"webinar-79.yaml": `track_event: true
+status: cancelled
+start_date: "2020-10-29T19:30:00+08:00"
+title: Webinar 79 - Serverless
+description: Some description
+duration: 90
+organizers:
+- name: Organizer
+  email: organizer@example.com
+`,
+	})
+	video := &youtubeapi.Video{Id: "abc123", Snippet: &youtubeapi.VideoSnippet{Title: "Webinar 78"}, Statistics: &youtubeapi.VideoStatistics{ViewCount: 42}}
+	s := EventStore{
+		store:          store,
+		logger:         logger.LoggerForTests{Tester: t},
+		featureControl: SubMeetupFeatureControl{SheetsReporterSync: true},
+		youtubeSvc:     youtubeServerHelper(t, video),
+	}
+	loc, _ := time.LoadLocation("Asia/Singapore")
+	WithTimeZone(loc)(&s)
+	calls := sheetsCalls{}
+	WithSheetsReporter(sheetsServerHelper(t, &rows, &calls), "spreadsheet-id")(&s)
+
+	e, _ := store.Get("webinar-80")
+	recent := e.StartDate.In(loc)
+
+	got, err := s.Sync(context.TODO(), SyncOptions{Platform: "sheets"})
+	if err != nil {
+		t.Fatalf("EventStore.Sync() error = %v", err)
+	}
+	wantActions := []Action{ActionUpdate, ActionCreate, ActionCreate}
+	for idx, c := range got.Changes {
+		if c.Action != wantActions[idx] || c.Error != "" {
+			t.Errorf("EventStore.Sync() %v = %v (%v), want %v", c.EventID, c.Action, c.Error, wantActions[idx])
+		}
+	}
+	wantRows := [][]string{
+		header,
+		{"webinar-78", "2020-10-15 19:30 +08", "Webinar 78 - Observability", "Jane Doe, John Doe", "", "https://youtu.be/abc123", "", "42", "meetup: error; streamyard: ok"},
+		{"webinar-79", "2020-10-29 19:30 +08", "Webinar 79 - Serverless", "", "", "", "", "", "cancelled"},
+		{"webinar-80", recent.Format("2006-01-02 15:04 MST"), "Webinar 80 - Tracing", "", "", "https://youtu.be/abc123", "", "42", ""},
+	}
+	if len(rows) != len(wantRows) {
+		t.Fatalf("EventStore.Sync() rows = %v, want %v", rows, wantRows)
+	}
+	for idx := range wantRows {
+		if strings.Join(rows[idx], "|") != strings.Join(wantRows[idx], "|") {
+			t.Errorf("EventStore.Sync() row %v = %v, want %v", idx, rows[idx], wantRows[idx])
+		}
+	}
+
+	if calls.reads != 1 {
+		t.Errorf("EventStore.Sync() read the spreadsheet %v times, want 1", calls.reads)
+	}
+
+	// Views are only retrieved again for recent events
+	video.Statistics.ViewCount = 50
+	got, err = s.Sync(context.TODO(), SyncOptions{Platform: "sheets"})
+	if err != nil {
+		t.Fatalf("EventStore.Sync() error = %v", err)
+	}
+	wantActions = []Action{ActionNoop, ActionNoop, ActionUpdate}
+	for idx, c := range got.Changes {
+		if c.Action != wantActions[idx] || c.Error != "" {
+			t.Errorf("EventStore.Sync() %v = %v (%v), want %v", c.EventID, c.Action, c.Error, wantActions[idx])
+		}
+	}
+	if rows[1][7] != "42" || rows[3][7] != "50" {
+		t.Errorf("EventStore.Sync() views = %v and %v, want 42 and 50", rows[1][7], rows[3][7])
+	}
+	if calls.reads != 2 {
+		t.Errorf("EventStore.Sync() read the spreadsheet %v times, want 2", calls.reads)
+	}
+}
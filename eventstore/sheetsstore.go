@@ -0,0 +1,617 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+	"github.com/hairizuanbinnoorazman/techmeetup/sheets"
+	"gopkg.in/yaml.v2"
+)
+
+// sheetsStoreColumns is the column layout of a sheets eventstore. Columns are matched by the
+// names in the first row of the sheet and may be in any order. Only id, title and start_date are
+// required. Values of missing columns, e.g. the platform IDs that are written back after a sync,
+// are not saved, so platforms may be synced again on the next sync.
+//
+// A single talk is entered with the topic, synopsis and speakers columns. Speakers are comma
+// separated speaker IDs from the speakers registry or "Name <email>". Any other agenda is kept as
+// yaml in the agenda column, which takes precedence over the single talk columns. Sponsors are
+// comma separated "Name <logo>" entries
+var sheetsStoreColumns = []string{
+	"id",
+	"track_event",
+	"status",
+	"title",
+	"start_date",
+	"timezone",
+	"duration",
+	"description",
+	"is_online",
+	"is_public",
+	"venue",
+	"organizers",
+	"topic",
+	"synopsis",
+	"speakers",
+	"agenda",
+	"featured_image_path",
+	"generate_banner_image",
+	"update_image_on_platforms",
+	"youtube_link",
+	"facebook_link",
+	"streamyard_id",
+	"meetup_id",
+	"calendar_event_id",
+	"banner_hash",
+	"series_id",
+	"series_date",
+	"archived",
+	"sponsors",
+	"slides_id",
+	"slides_text",
+	"slides_shapes",
+	"sync_status",
+}
+
+var sheetsRequiredColumns = []string{"id", "title", "start_date"}
+
+// sheetsBoolColumns are written as booleans so that they work with checkboxes
+var sheetsBoolColumns = map[string]bool{
+	"track_event":               true,
+	"is_online":                 true,
+	"is_public":                 true,
+	"generate_banner_image":     true,
+	"update_image_on_platforms": true,
+	"archived":                  true,
+}
+
+// start_date is written in sheetsDateFormat for events with a time zone. Dates without an offset
+// are in the time zone of the event or the default time zone
+const sheetsDateFormat = "2006-01-02T15:04:05"
+
+var sheetsDateLayouts = []string{sheetsDateFormat, "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+// SheetsStore keeps events in a google sheet with a row per event so that organizers are able to
+// manage events without editing yaml. Only the cells that changed are written when events are
+// saved, so cells that hold the same value in another format, e.g. a date entered without
+// seconds, and other columns, e.g. formulas, are left as they are.
+//
+// The sheet is read by List and Get. Put and Delete work on the rows of the last read so that a
+// sync only reads the sheet once, which means that rows should not be moved during a sync
+type SheetsStore struct {
+	logger        logger.Logger
+	sheetsSvc     sheets.GoogleSheets
+	spreadsheetID string
+	sheetName     string
+	location      *time.Location
+	mu            sync.Mutex
+	// table is the content of the sheet from the last read. Nil until the sheet is read
+	table *sheetsTable
+}
+
+// NewSheetsStore reads events from the sheet of the spreadsheet. An empty sheet name refers to the
+// first sheet. loc is the time zone of dates without an offset in events without a time zone
+func NewSheetsStore(logger logger.Logger, sheetsSvc sheets.GoogleSheets, spreadsheetID, sheetName string, loc *time.Location) *SheetsStore {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &SheetsStore{
+		logger:        logger,
+		sheetsSvc:     sheetsSvc,
+		spreadsheetID: spreadsheetID,
+		sheetName:     sheetName,
+		location:      loc,
+	}
+}
+
+// sheetsTable is the content of the events sheet along with the column of each field
+type sheetsTable struct {
+	columns map[string]int
+	rows    [][]string
+}
+
+func (t sheetsTable) cells(row int) map[string]string {
+	cells := map[string]string{}
+	for name, idx := range t.columns {
+		cells[name] = strings.TrimSpace(sheetsCell(t.rows[row], idx))
+	}
+	return cells
+}
+
+func (d *SheetsStore) List() ([]Event, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	t, err := d.read(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	d.table = &t
+	data, _, err := d.events(t)
+	return data, err
+}
+
+func (d *SheetsStore) Get(id string) (Event, error) {
+	data, err := d.List()
+	if err != nil {
+		return Event{}, err
+	}
+	for _, e := range data {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return Event{}, ErrEventNotFound
+}
+
+// Put only writes the cells of the row that changed. New events are added after the last row
+func (d *SheetsStore) Put(e Event) error {
+	if e.ID == "" {
+		return fmt.Errorf("Event ID is missing. Title: %v", e.Title)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	ctx := context.TODO()
+	t, err := d.lastRead(ctx)
+	if err != nil {
+		return err
+	}
+	data, rowIdx, err := d.events(*t)
+	if err != nil {
+		return err
+	}
+	_, hasTopic := t.columns["topic"]
+	_, hasSpeakers := t.columns["speakers"]
+	singleTalk := hasTopic && hasSpeakers
+	row := -1
+	previous := map[string]string{}
+	for idx, existing := range data {
+		if existing.ID != e.ID {
+			continue
+		}
+		row = rowIdx[idx]
+		previous, err = sheetsEventCells(existing, singleTalk)
+		if err != nil {
+			return err
+		}
+		// Generated IDs are written to the sheet so that the event keeps its ID if the title changes
+		previous["id"] = t.cells(row)["id"]
+		break
+	}
+
+	current, err := sheetsEventCells(e, singleTalk)
+	if err != nil {
+		return err
+	}
+	changed := map[int]string{}
+	missing := []string{}
+	for _, name := range sheetsStoreColumns {
+		if current[name] == previous[name] {
+			continue
+		}
+		idx, ok := t.columns[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		changed[idx] = current[name]
+	}
+	// The other columns are still saved as they may hold the IDs of events that were just
+	// created on the platforms
+	if len(missing) > 0 {
+		d.logger.Warningf("Columns %v are missing in the events sheet and are not saved for event %v. Add the columns to keep them", strings.Join(missing, ", "), e.ID)
+	}
+
+	if row < 0 {
+		values := make([]string, len(t.rows[0]))
+		for idx, value := range changed {
+			values[idx] = value
+		}
+		err = d.sheetsSvc.AppendRow(ctx, d.spreadsheetID, d.sheetName, values)
+		if err != nil {
+			return err
+		}
+		t.rows = append(t.rows, values)
+		return nil
+	}
+
+	cells := map[int]interface{}{}
+	for name, idx := range t.columns {
+		if value, ok := changed[idx]; ok {
+			cells[idx] = value
+			if sheetsBoolColumns[name] {
+				cells[idx] = parseSheetsBool(value)
+			}
+		}
+	}
+	err = d.sheetsSvc.UpdateCells(ctx, d.spreadsheetID, d.sheetName, row+1, cells)
+	if err != nil {
+		return err
+	}
+	values := append([]string{}, t.rows[row]...)
+	for idx, value := range changed {
+		for len(values) <= idx {
+			values = append(values, "")
+		}
+		values[idx] = value
+	}
+	t.rows[row] = values
+	return nil
+}
+
+// Delete empties the row of the event
+func (d *SheetsStore) Delete(id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	ctx := context.TODO()
+	t, err := d.lastRead(ctx)
+	if err != nil {
+		return err
+	}
+	data, rowIdx, err := d.events(*t)
+	if err != nil {
+		return err
+	}
+	for idx, e := range data {
+		if e.ID == id {
+			err = d.sheetsSvc.ClearRow(ctx, d.spreadsheetID, d.sheetName, rowIdx[idx]+1)
+			if err != nil {
+				return err
+			}
+			t.rows[rowIdx[idx]] = []string{}
+			return nil
+		}
+	}
+	return ErrEventNotFound
+}
+
+// lastRead is the content of the sheet from the last read. The sheet is read if it has not
+// been read yet. The lock needs to be held
+func (d *SheetsStore) lastRead(ctx context.Context) (*sheetsTable, error) {
+	if d.table != nil {
+		return d.table, nil
+	}
+	t, err := d.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.table = &t
+	return d.table, nil
+}
+
+func (d *SheetsStore) read(ctx context.Context) (sheetsTable, error) {
+	rows, err := d.sheetsSvc.GetRows(ctx, d.spreadsheetID, d.sheetName)
+	if err != nil {
+		return sheetsTable{}, err
+	}
+	if len(rows) == 0 {
+		return sheetsTable{}, fmt.Errorf("Events sheet is empty. The first row needs to have the column names")
+	}
+	columns := map[string]int{}
+	for idx, name := range rows[0] {
+		name = strings.ToLower(strings.Join(strings.Fields(name), "_"))
+		if name != "" {
+			columns[name] = idx
+		}
+	}
+	for _, name := range sheetsRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return sheetsTable{}, fmt.Errorf("Column %v is missing in the events sheet", name)
+		}
+	}
+	return sheetsTable{columns: columns, rows: rows}, nil
+}
+
+// events parses the rows of the sheet. The index of the row of each event is returned as well.
+// Empty rows are skipped
+func (d *SheetsStore) events(t sheetsTable) ([]Event, []int, error) {
+	data := []Event{}
+	rowIdx := []int{}
+	for idx := 1; idx < len(t.rows); idx++ {
+		if strings.TrimSpace(strings.Join(t.rows[idx], "")) == "" {
+			continue
+		}
+		e, err := d.parseCells(t.cells(idx))
+		if err != nil {
+			return nil, nil, fmt.Errorf("Unable to read row %v of events sheet. Err: %v", idx+1, err)
+		}
+		data = append(data, e)
+		rowIdx = append(rowIdx, idx)
+	}
+	return data, rowIdx, nil
+}
+
+func (d *SheetsStore) parseCells(c map[string]string) (Event, error) {
+	var err error
+	e := Event{
+		ID:                     c["id"],
+		TrackEvent:             parseSheetsBool(c["track_event"]),
+		Status:                 strings.ToLower(c["status"]),
+		Title:                  c["title"],
+		TimeZone:               c["timezone"],
+		Description:            c["description"],
+		IsOnline:               parseSheetsBool(c["is_online"]),
+		IsPublic:               parseSheetsBool(c["is_public"]),
+		Venue:                  c["venue"],
+		Organizers:             parseSheetsOrganizers(c["organizers"]),
+		FeaturedImagePath:      c["featured_image_path"],
+		GenerateBannerImage:    parseSheetsBool(c["generate_banner_image"]),
+		UpdateImageOnPlatforms: parseSheetsBool(c["update_image_on_platforms"]),
+		YoutubeLink:            c["youtube_link"],
+		FacebookLink:           c["facebook_link"],
+		StreamyardID:           c["streamyard_id"],
+		MeetupID:               c["meetup_id"],
+		CalendarEventID:        c["calendar_event_id"],
+		BannerHash:             c["banner_hash"],
+		SeriesID:               c["series_id"],
+		SeriesDate:             c["series_date"],
+		Archived:               parseSheetsBool(c["archived"]),
+		Sponsors:               parseSheetsSponsors(c["sponsors"]),
+		SlidesID:               c["slides_id"],
+	}
+	e.StartDate, err = d.parseDate(c["start_date"], e.TimeZone)
+	if err != nil {
+		return Event{}, err
+	}
+	if c["duration"] != "" {
+		e.Duration, err = strconv.Atoi(c["duration"])
+		if err != nil {
+			return Event{}, fmt.Errorf("Invalid duration %v. Duration is in minutes", c["duration"])
+		}
+	}
+	e.Agenda, err = parseSheetsAgenda(c)
+	if err != nil {
+		return Event{}, err
+	}
+	if c["slides_text"] != "" {
+		err = json.Unmarshal([]byte(c["slides_text"]), &e.SlidesText)
+		if err != nil {
+			return Event{}, fmt.Errorf("Unable to parse slides_text. Err: %v", err)
+		}
+	}
+	if c["slides_shapes"] != "" {
+		err = json.Unmarshal([]byte(c["slides_shapes"]), &e.SlidesShapes)
+		if err != nil {
+			return Event{}, fmt.Errorf("Unable to parse slides_shapes. Err: %v", err)
+		}
+	}
+	if c["sync_status"] != "" {
+		err = json.Unmarshal([]byte(c["sync_status"]), &e.SyncStatus)
+		if err != nil {
+			return Event{}, fmt.Errorf("Unable to parse sync_status. Err: %v", err)
+		}
+	}
+	if e.ID == "" {
+		e.ID = GenerateEventID(e)
+	}
+	return e, nil
+}
+
+func (d *SheetsStore) parseDate(value, timeZone string) (time.Time, error) {
+	loc := d.location
+	if timeZone != "" {
+		var err error
+		loc, err = time.LoadLocation(timeZone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("Unable to load timezone: Err: %v", err)
+		}
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		if timeZone != "" {
+			t = t.In(loc)
+		}
+		return t, nil
+	}
+	for _, layout := range sheetsDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Unable to parse start_date %q. Use the 2006-01-02 15:04 format", value)
+}
+
+// sheetsEventCells is the value of each column for the event. Default values are empty so that
+// columns that are not used may be left out of the sheet. The agenda is only written into the
+// single talk columns if singleTalk is set
+func sheetsEventCells(e Event, singleTalk bool) (map[string]string, error) {
+	startDate := e.StartDate.Format(time.RFC3339)
+	if e.TimeZone != "" {
+		startDate = e.StartDate.Format(sheetsDateFormat)
+	}
+	duration := ""
+	if e.Duration != 0 {
+		duration = strconv.Itoa(e.Duration)
+	}
+	organizers := []string{}
+	for _, o := range e.Organizers {
+		organizers = append(organizers, sheetsContact(o.Name, o.Email))
+	}
+	syncStatus := ""
+	if len(e.SyncStatus) > 0 {
+		raw, err := json.Marshal(e.SyncStatus)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to marshal sync status of event %v. Err: %v", e.ID, err)
+		}
+		syncStatus = string(raw)
+	}
+	slidesText := ""
+	if len(e.SlidesText) > 0 {
+		raw, err := json.Marshal(e.SlidesText)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to marshal slides text of event %v. Err: %v", e.ID, err)
+		}
+		slidesText = string(raw)
+	}
+	slidesShapes := ""
+	if len(e.SlidesShapes) > 0 {
+		raw, err := json.Marshal(e.SlidesShapes)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to marshal slides shapes of event %v. Err: %v", e.ID, err)
+		}
+		slidesShapes = string(raw)
+	}
+	sponsors := []string{}
+	for _, sp := range e.Sponsors {
+		sponsors = append(sponsors, sheetsContact(sp.Name, sp.Logo))
+	}
+	cells := map[string]string{
+		"id":                        e.ID,
+		"track_event":               formatSheetsBool(e.TrackEvent),
+		"status":                    e.Status,
+		"title":                     e.Title,
+		"start_date":                startDate,
+		"timezone":                  e.TimeZone,
+		"duration":                  duration,
+		"description":               e.Description,
+		"is_online":                 formatSheetsBool(e.IsOnline),
+		"is_public":                 formatSheetsBool(e.IsPublic),
+		"venue":                     e.Venue,
+		"organizers":                strings.Join(organizers, ", "),
+		"featured_image_path":       e.FeaturedImagePath,
+		"generate_banner_image":     formatSheetsBool(e.GenerateBannerImage),
+		"update_image_on_platforms": formatSheetsBool(e.UpdateImageOnPlatforms),
+		"youtube_link":              e.YoutubeLink,
+		"facebook_link":             e.FacebookLink,
+		"streamyard_id":             e.StreamyardID,
+		"meetup_id":                 e.MeetupID,
+		"calendar_event_id":         e.CalendarEventID,
+		"banner_hash":               e.BannerHash,
+		"series_id":                 e.SeriesID,
+		"series_date":               e.SeriesDate,
+		"archived":                  formatSheetsBool(e.Archived),
+		"sponsors":                  strings.Join(sponsors, ", "),
+		"slides_id":                 e.SlidesID,
+		"slides_text":               slidesText,
+		"slides_shapes":             slidesShapes,
+		"sync_status":               syncStatus,
+	}
+	if talk, ok := sheetsTalk(e.Agenda); ok && singleTalk {
+		cells["topic"] = talk.Topic
+		cells["synopsis"] = talk.Synopsis
+		speakers := []string{}
+		for _, sp := range talk.Speakers {
+			speakers = append(speakers, sheetsContact(sp.Name, sp.Email))
+		}
+		cells["speakers"] = strings.Join(append(speakers, talk.SpeakerIDs...), ", ")
+	} else {
+		raw, err := yaml.Marshal(e.Agenda)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to marshal agenda of event %v. Err: %v", e.ID, err)
+		}
+		cells["agenda"] = strings.TrimSpace(string(raw))
+	}
+	return cells, nil
+}
+
+// sheetsTalk returns the only talk of the agenda if it fits the topic, synopsis and speakers
+// columns. Events without an agenda fit as well
+func sheetsTalk(agenda []AgendaItem) (AgendaItem, bool) {
+	if len(agenda) == 0 {
+		return AgendaItem{}, true
+	}
+	a := agenda[0]
+	if len(agenda) > 1 || a.Type != "speaker" || a.Duration != 0 || a.SlidesLink != "" {
+		return AgendaItem{}, false
+	}
+	for _, sp := range a.Speakers {
+		if sp.ID != "" || sp.Email == "" || sp.Profile != "" || sp.ProfileImage != "" || strings.Contains(sp.Name, ",") {
+			return AgendaItem{}, false
+		}
+	}
+	return a, true
+}
+
+func parseSheetsAgenda(c map[string]string) ([]AgendaItem, error) {
+	if c["agenda"] != "" {
+		var agenda []AgendaItem
+		err := yaml.Unmarshal([]byte(c["agenda"]), &agenda)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to parse agenda. Err: %v", err)
+		}
+		return agenda, nil
+	}
+	if c["topic"] == "" && c["synopsis"] == "" && c["speakers"] == "" {
+		return nil, nil
+	}
+	talk := AgendaItem{Type: "speaker", Topic: c["topic"], Synopsis: c["synopsis"]}
+	for _, entry := range splitSheetsList(c["speakers"]) {
+		name, email, ok := parseSheetsContact(entry)
+		if ok {
+			talk.Speakers = append(talk.Speakers, Speaker{Name: name, Email: email})
+			continue
+		}
+		talk.SpeakerIDs = append(talk.SpeakerIDs, entry)
+	}
+	return []AgendaItem{talk}, nil
+}
+
+func parseSheetsOrganizers(value string) []Organizer {
+	var organizers []Organizer
+	for _, entry := range splitSheetsList(value) {
+		name, email, ok := parseSheetsContact(entry)
+		if !ok {
+			name = entry
+		}
+		organizers = append(organizers, Organizer{Name: name, Email: email})
+	}
+	return organizers
+}
+
+func parseSheetsSponsors(value string) []Sponsor {
+	var sponsors []Sponsor
+	for _, entry := range splitSheetsList(value) {
+		name, logo, ok := parseSheetsContact(entry)
+		if !ok {
+			name = entry
+		}
+		sponsors = append(sponsors, Sponsor{Name: name, Logo: logo})
+	}
+	return sponsors
+}
+
+func splitSheetsList(value string) []string {
+	entries := []string{}
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// parseSheetsContact parses entries in the "Name <email>" format
+func parseSheetsContact(entry string) (string, string, bool) {
+	start := strings.LastIndex(entry, "<")
+	if start < 0 || !strings.HasSuffix(entry, ">") {
+		return "", "", false
+	}
+	return strings.TrimSpace(entry[:start]), strings.TrimSpace(entry[start+1 : len(entry)-1]), true
+}
+
+func sheetsContact(name, email string) string {
+	if email == "" {
+		return name
+	}
+	return fmt.Sprintf("%v <%v>", name, email)
+}
+
+func parseSheetsBool(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "yes", "y", "1", "x":
+		return true
+	default:
+		return false
+	}
+}
+
+func formatSheetsBool(value bool) string {
+	if value {
+		return "TRUE"
+	}
+	return ""
+}
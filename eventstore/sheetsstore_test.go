@@ -0,0 +1,135 @@
+package eventstore
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+)
+
+func sheetsStoreRowsHelper() [][]string {
+	return [][]string{
+		{"ID", "Track Event", "Title", "Start Date", "Duration", "Is Online", "Organizers", "Topic", "Speakers", "Agenda", "Meetup ID", "Notes"},
+		{"webinar-78", "TRUE", "Webinar 78 - Observability", "2020-10-15 19:30", "90", "TRUE", "Organizer <organizer@example.com>", "Observability", "jane, John Doe <john@example.com>", "", "", "Keep this"},
+		{},
+		{"", "FALSE", "Webinar 79 - Serverless", "2020-10-29T19:30:00+08:00", "", "", "", "", "", "- type: break\n- type: speaker\n  topic: Serverless\n  speaker_ids: [jane]"},
+	}
+}
+
+func TestSheetsStore_List(t *testing.T) {
+	rows := sheetsStoreRowsHelper()
+	loc, _ := time.LoadLocation("Asia/Singapore")
+	d := NewSheetsStore(logger.LoggerForTests{Tester: t}, sheetsServerHelper(t, &rows, nil), "spreadsheet-id", "", loc)
+	got, err := d.List()
+	if err != nil {
+		t.Fatalf("SheetsStore.List() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("SheetsStore.List() = %v events, want 2", len(got))
+	}
+	e := got[0]
+	if !e.TrackEvent || !e.IsOnline || e.Duration != 90 || !e.StartDate.Equal(time.Date(2020, 10, 15, 19, 30, 0, 0, loc)) {
+		t.Errorf("SheetsStore.List() = %+v, want the values of the first row", e)
+	}
+	if len(e.Organizers) != 1 || e.Organizers[0].Email != "organizer@example.com" {
+		t.Errorf("SheetsStore.List() organizers = %+v", e.Organizers)
+	}
+	if len(e.Agenda) != 1 || e.Agenda[0].Topic != "Observability" || strings.Join(e.Agenda[0].SpeakerIDs, ",") != "jane" || len(e.Agenda[0].Speakers) != 1 || e.Agenda[0].Speakers[0].Email != "john@example.com" {
+		t.Errorf("SheetsStore.List() agenda = %+v", e.Agenda)
+	}
+	if got[1].ID != "20201029-webinar-79-serverless" || len(got[1].Agenda) != 2 {
+		t.Errorf("SheetsStore.List() = %+v, want generated ID and agenda from yaml", got[1])
+	}
+}
+
+func TestSheetsStore_Put(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     func(d *SheetsStore) Event
+		wantRow   int
+		wantRows  []string
+		wantCells []string
+	}{
+		{
+			name: "Platform ID is written back and other cells are kept",
+			event: func(d *SheetsStore) Event {
+				e, _ := d.Get("webinar-78")
+				e.MeetupID = "273683462"
+				return e
+			},
+			wantRow:   1,
+			wantRows:  []string{"webinar-78", "TRUE", "Webinar 78 - Observability", "2020-10-15 19:30", "90", "TRUE", "Organizer <organizer@example.com>", "Observability", "jane, John Doe <john@example.com>", "", "273683462", "Keep this"},
+			wantCells: []string{"K2"},
+		},
+		{
+			name: "Generated ID is written",
+			event: func(d *SheetsStore) Event {
+				e, _ := d.Get("20201029-webinar-79-serverless")
+				e.TrackEvent = true
+				return e
+			},
+			wantRow:   3,
+			wantRows:  []string{"20201029-webinar-79-serverless", "TRUE", "Webinar 79 - Serverless", "2020-10-29T19:30:00+08:00", "", "", "", "", "", "- type: break\n- type: speaker\n  topic: Serverless\n  speaker_ids: [jane]"},
+			wantCells: []string{"A4", "B4"},
+		},
+		{
+			name: "New event is appended",
+			event: func(d *SheetsStore) Event {
+				return Event{ID: "webinar-80", Title: "Webinar 80", StartDate: time.Date(2020, 11, 5, 19, 30, 0, 0, d.location), Agenda: []AgendaItem{{Type: "speaker", Topic: "Tracing", SpeakerIDs: []string{"jane"}}}}
+			},
+			wantRow:   4,
+			wantRows:  []string{"webinar-80", "", "Webinar 80", "2020-11-05T19:30:00+08:00", "", "", "", "Tracing", "jane", "", "", ""},
+			wantCells: []string{},
+		},
+		{
+			name: "Values of missing columns are not saved",
+			event: func(d *SheetsStore) Event {
+				e, _ := d.Get("webinar-78")
+				e.MeetupID = "273683462"
+				e.CalendarEventID = "abc"
+				e.SyncStatus = map[string]PlatformSyncStatus{"meetup": {LastError: "Unable to update meetup event"}}
+				return e
+			},
+			wantRow:   1,
+			wantRows:  []string{"webinar-78", "TRUE", "Webinar 78 - Observability", "2020-10-15 19:30", "90", "TRUE", "Organizer <organizer@example.com>", "Observability", "jane, John Doe <john@example.com>", "", "273683462", "Keep this"},
+			wantCells: []string{"K2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := sheetsStoreRowsHelper()
+			loc, _ := time.LoadLocation("Asia/Singapore")
+			calls := sheetsCalls{}
+			d := NewSheetsStore(logger.LoggerForTests{Tester: t}, sheetsServerHelper(t, &rows, &calls), "spreadsheet-id", "", loc)
+			if err := d.Put(tt.event(d)); err != nil {
+				t.Fatalf("SheetsStore.Put() error = %v", err)
+			}
+			if strings.Join(rows[tt.wantRow], "|") != strings.Join(tt.wantRows, "|") {
+				t.Errorf("SheetsStore.Put() row = %q, want %q", rows[tt.wantRow], tt.wantRows)
+			}
+			// Only the cells that changed are written
+			if strings.Join(calls.cells, ",") != strings.Join(tt.wantCells, ",") {
+				t.Errorf("SheetsStore.Put() wrote cells %v, want %v", calls.cells, tt.wantCells)
+			}
+			// The sheet is not read again after the event was read
+			if calls.reads != 1 {
+				t.Errorf("SheetsStore.Put() read the sheet %v times, want 1", calls.reads)
+			}
+		})
+	}
+}
+
+func TestSheetsStore_Delete(t *testing.T) {
+	rows := sheetsStoreRowsHelper()
+	d := NewSheetsStore(logger.LoggerForTests{Tester: t}, sheetsServerHelper(t, &rows, nil), "spreadsheet-id", "", time.UTC)
+	if err := d.Delete("webinar-78"); err != nil {
+		t.Fatalf("SheetsStore.Delete() error = %v", err)
+	}
+	if _, err := d.Get("webinar-78"); err != ErrEventNotFound {
+		t.Errorf("SheetsStore.Get() error = %v, want %v", err, ErrEventNotFound)
+	}
+	if len(rows) != 4 {
+		t.Errorf("SheetsStore.Delete() removed the row instead of clearing it")
+	}
+}
@@ -0,0 +1,300 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/slides"
+)
+
+// slidesDeck creates the slides of each event by copying a template presentation. Text in the
+// template in the form of {{placeholder}} is replaced with the details of the event, e.g.
+// {{title}}, {{date}}, {{talk1_topic}}, {{speaker1_name}}. Shapes that contain the text
+// {{speaker1_photo}} or {{sponsor1_logo}} are replaced with the images.
+//
+// The text with placeholders of each shape is kept so that only those shapes are filled in again
+// when the event changes. The slides keep their ID and other content added by organizers
+type slidesDeck struct {
+	slidesSvc  slides.GoogleSlides
+	templateID string
+	folderID   string
+}
+
+// WithSlides sets the template presentation that the slides of each event are created from.
+// Presentations are created in the drive folder if it is set. Slides are not created if the
+// template ID is empty
+func WithSlides(slidesSvc slides.GoogleSlides, templateID, folderID string) Option {
+	return func(s *EventStore) {
+		if templateID == "" {
+			return
+		}
+		s.slidesDeck = &slidesDeck{
+			slidesSvc:  slidesSvc,
+			templateID: templateID,
+			folderID:   folderID,
+		}
+	}
+}
+
+func slidesPlaceholder(key string) string {
+	return "{{" + key + "}}"
+}
+
+// slidesText is the text of each placeholder for the event. Placeholders without a value are
+// left as they are so that they are easy to spot in the presentation
+func slidesText(e Event, v Venue) map[string]string {
+	text := map[string]string{
+		"title": e.Title,
+		"date":  e.StartDate.Format("Monday, 2 January 2006"),
+		"time":  e.StartDate.Format("3:04 PM"),
+		"venue": v.Name,
+	}
+	if !e.IsInPerson() && e.IsOnline {
+		text["venue"] = "Online"
+	}
+	agenda := []string{}
+	talk := 0
+	for _, a := range e.Agenda {
+		if a.Type == "break" {
+			agenda = append(agenda, "Break")
+			continue
+		}
+		talk++
+		agenda = append(agenda, fmt.Sprintf("%v - %v", a.Topic, speakerNames(a.Speakers)))
+		text[fmt.Sprintf("talk%v_topic", talk)] = a.Topic
+		text[fmt.Sprintf("talk%v_speakers", talk)] = speakerNames(a.Speakers)
+		text[fmt.Sprintf("talk%v_synopsis", talk)] = a.Synopsis
+	}
+	text["agenda"] = strings.Join(agenda, "\n")
+	for idx, sp := range e.Speakers() {
+		text[fmt.Sprintf("speaker%v_name", idx+1)] = sp.Name
+		text[fmt.Sprintf("speaker%v_bio", idx+1)] = sp.Profile
+	}
+	for idx, sp := range e.Sponsors {
+		text[fmt.Sprintf("sponsor%v_name", idx+1)] = sp.Name
+	}
+	for key, value := range text {
+		if value == "" {
+			text[key] = slidesPlaceholder(key)
+		}
+	}
+	return text
+}
+
+// slidesImages are the images of each placeholder for the event. Images can only be placed
+// once as the shape with the placeholder is replaced by the image
+func slidesImages(e Event) map[string]string {
+	images := map[string]string{}
+	for idx, sp := range e.Speakers() {
+		if sp.ProfileImage != "" {
+			images[fmt.Sprintf("speaker%v_photo", idx+1)] = sp.ProfileImage
+		}
+	}
+	for idx, sp := range e.Sponsors {
+		if sp.Logo != "" {
+			images[fmt.Sprintf("sponsor%v_logo", idx+1)] = sp.Logo
+		}
+	}
+	return images
+}
+
+// isSlidesImage is true for the placeholders of images
+func isSlidesImage(key string) bool {
+	return strings.HasSuffix(key, "_photo") || strings.HasSuffix(key, "_logo")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *EventStore) planSlides(ctx context.Context, e Event) Change {
+	c := newChange(e, "slides")
+	if !s.featureControl.SlidesSync {
+		return c.skip("Slides sync is disabled")
+	}
+
+	if s.slidesDeck == nil {
+		return c.skip("Slides template is not set in config")
+	}
+
+	if e.Status == StatusCancelled {
+		c.Reason = "Event is cancelled. Slides are left as they are"
+		return c
+	}
+
+	if !time.Now().Before(e.StartDate) {
+		return c.skip("Event has started. Slides are no longer updated")
+	}
+
+	e, err := s.withSpeakers(e)
+	if err != nil {
+		return c.fail(err)
+	}
+	v, err := s.venue(e)
+	if err != nil {
+		return c.fail(err)
+	}
+	expected := slidesText(e, v)
+	// Images are kept by their path so that slides are updated when images change
+	for key, image := range slidesImages(e) {
+		expected[key] = image
+	}
+	// Placeholders that are no longer in use, e.g. a speaker dropped out, are reverted
+	for key := range e.SlidesText {
+		if _, ok := expected[key]; !ok {
+			expected[key] = slidesPlaceholder(key)
+		}
+	}
+	for _, key := range sortedKeys(expected) {
+		before, ok := e.SlidesText[key]
+		if !ok || e.SlidesID == "" {
+			before = slidesPlaceholder(key)
+		}
+		c.diff(key, before, expected[key])
+	}
+	switch {
+	case e.SlidesID == "":
+		c.Action = ActionCreate
+		c.diff("slides_id", "", knownAfterApply)
+	case len(c.Diffs) > 0:
+		c.Action = ActionUpdate
+	}
+	return c
+}
+
+func (s *EventStore) applySlides(ctx context.Context, e Event, c Change) (Event, error) {
+	values := map[string]string{}
+	for key, value := range c.payload {
+		if key != "slides_id" {
+			values[key] = value
+		}
+	}
+
+	if e.SlidesID == "" {
+		s.logger.Infof("Creating slides for event: %v", e.Title)
+		slidesID, err := s.slidesDeck.slidesSvc.CopyPresentation(ctx, s.slidesDeck.templateID, e.Title, s.slidesDeck.folderID)
+		if err != nil {
+			return e, fmt.Errorf("Unable to create slides. Err: %v", err)
+		}
+		e.SlidesID = slidesID
+		e.SlidesShapes = nil
+	} else {
+		s.logger.Infof("Updating slides of event: %v SlidesID: %v", e.Title, e.SlidesID)
+	}
+	shapes, err := s.fillSlides(ctx, e, values)
+	if err != nil {
+		return e, fmt.Errorf("Unable to fill in slides. Err: %v", err)
+	}
+	e.SlidesText = values
+	e.SlidesShapes = shapes
+	return e, nil
+}
+
+// fillSlides fills in the placeholders of the slides with the values. The shapes that held
+// placeholders are reset to their text with placeholders first. Slides that were not filled in
+// yet have their shapes with placeholders looked up. Images are uploaded to drive for the slides
+// to fetch them and deleted once they are placed. The shapes with placeholders are returned
+func (s *EventStore) fillSlides(ctx context.Context, e Event, values map[string]string) (map[string]string, error) {
+	svc := s.slidesDeck.slidesSvc
+	elements, err := svc.PageElements(ctx, e.SlidesID)
+	if err != nil {
+		return nil, err
+	}
+	byID := map[string]slides.PageElement{}
+	recorded := e.SlidesShapes
+	if recorded == nil {
+		recorded = map[string]string{}
+	}
+	for _, el := range elements {
+		byID[el.ObjectID] = el
+		if e.SlidesShapes == nil && !el.IsImage && strings.Contains(el.Text, "{{") {
+			recorded[el.ObjectID] = el.Text
+		}
+	}
+
+	uploaded := []string{}
+	defer func() {
+		for _, fileID := range uploaded {
+			err := svc.DeleteFile(ctx, fileID)
+			if err != nil {
+				s.logger.Warningf("Unable to delete image uploaded for slides. It is still shared with anyone with the link. Err: %v", err)
+			}
+		}
+	}()
+	upload := func(path string) (string, error) {
+		image, err := svc.UploadImage(ctx, path)
+		if image.FileID != "" {
+			uploaded = append(uploaded, image.FileID)
+		}
+		return image.URL, err
+	}
+
+	update := slides.Update{}
+	shapes := map[string]string{}
+	for _, id := range sortedKeys(recorded) {
+		text := recorded[id]
+		el, ok := byID[id]
+		if !ok {
+			s.logger.Warningf("Shape with %q was removed from the slides of event: %v and is no longer filled in", text, e.Title)
+			continue
+		}
+		key := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(text), "{{"), "}}")
+		image, hasImage := values[key]
+		hasImage = hasImage && isSlidesImage(key) && slidesPlaceholder(key) == strings.TrimSpace(text) && image != slidesPlaceholder(key)
+		switch {
+		case el.IsImage:
+			shapes[id] = text
+			if !hasImage {
+				s.logger.Warningf("Image %v is no longer set for event: %v. The image is kept on the slides", key, e.Title)
+				continue
+			}
+			if image == e.SlidesText[key] {
+				continue
+			}
+			imageURL, err := upload(image)
+			if err != nil {
+				return nil, err
+			}
+			update.ReplaceImage(el, imageURL)
+		case hasImage:
+			imageURL, err := upload(image)
+			if err != nil {
+				return nil, err
+			}
+			imageID := "image_" + id
+			update.ShapeToImage(el, imageID, imageURL)
+			shapes[imageID] = text
+		default:
+			shapes[id] = text
+			if el.Text != text {
+				update.SetText(el, text)
+			}
+		}
+	}
+	for _, key := range sortedKeys(values) {
+		if isSlidesImage(key) || values[key] == slidesPlaceholder(key) {
+			continue
+		}
+		update.ReplaceAllText(slidesPlaceholder(key), values[key])
+	}
+	err = svc.Apply(ctx, e.SlidesID, update)
+	if err != nil {
+		return nil, err
+	}
+	return shapes, nil
+}
+
+func expectedSlides(e Event) Event {
+	if e.SlidesID == "" {
+		e.SlidesID = knownAfterApply
+	}
+	return e
+}
@@ -0,0 +1,339 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+	"github.com/hairizuanbinnoorazman/techmeetup/slides"
+	driveapi "google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+	slidesapi "google.golang.org/api/slides/v1"
+)
+
+// slidesElement is a shape with text or an image if image is set
+type slidesElement struct {
+	page  string
+	text  string
+	image string
+}
+
+// slidesFake is a drive with presentations that only hold shapes with text and images
+type slidesFake struct {
+	// decks are the elements of each presentation by object ID. Presentations are copied from
+	// template-id
+	decks   map[string]map[string]slidesElement
+	copies  int
+	updates int
+	// images are the uploaded images that were not deleted yet
+	images map[string]bool
+}
+
+// render lists the elements of the presentation in object ID order. Images are shown as
+// [image name]
+func (f *slidesFake) render(id string) string {
+	ids := []string{}
+	for objectID := range f.decks[id] {
+		ids = append(ids, objectID)
+	}
+	sort.Strings(ids)
+	lines := []string{}
+	for _, objectID := range ids {
+		el := f.decks[id][objectID]
+		if el.image != "" {
+			lines = append(lines, objectID+": ["+el.image+"]")
+			continue
+		}
+		lines = append(lines, objectID+": "+el.text)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// apply applies the requests one after another. None of them are applied if any of them fails
+func (f *slidesFake) apply(id string, reqs []*slidesapi.Request) bool {
+	deck := map[string]slidesElement{}
+	for objectID, el := range f.decks[id] {
+		deck[objectID] = el
+	}
+	for _, req := range reqs {
+		switch {
+		case req.DeleteText != nil:
+			el, ok := deck[req.DeleteText.ObjectId]
+			if !ok || el.image != "" || el.text == "" {
+				return false
+			}
+			el.text = ""
+			deck[req.DeleteText.ObjectId] = el
+		case req.InsertText != nil:
+			el, ok := deck[req.InsertText.ObjectId]
+			if !ok || el.image != "" {
+				return false
+			}
+			el.text = req.InsertText.Text + el.text
+			deck[req.InsertText.ObjectId] = el
+		case req.ReplaceAllText != nil:
+			for objectID, el := range deck {
+				el.text = strings.ReplaceAll(el.text, req.ReplaceAllText.ContainsText.Text, req.ReplaceAllText.ReplaceText)
+				deck[objectID] = el
+			}
+		case req.CreateImage != nil:
+			image := strings.TrimPrefix(req.CreateImage.Url, "https://drive.google.com/uc?export=download&id=")
+			if _, ok := deck[req.CreateImage.ObjectId]; ok || !f.images[image] {
+				return false
+			}
+			deck[req.CreateImage.ObjectId] = slidesElement{page: req.CreateImage.ElementProperties.PageObjectId, image: image}
+		case req.ReplaceImage != nil:
+			image := strings.TrimPrefix(req.ReplaceImage.Url, "https://drive.google.com/uc?export=download&id=")
+			el, ok := deck[req.ReplaceImage.ImageObjectId]
+			if !ok || el.image == "" || !f.images[image] {
+				return false
+			}
+			el.image = image
+			deck[req.ReplaceImage.ImageObjectId] = el
+		case req.DeleteObject != nil:
+			if _, ok := deck[req.DeleteObject.ObjectId]; !ok {
+				return false
+			}
+			delete(deck, req.DeleteObject.ObjectId)
+		default:
+			return false
+		}
+	}
+	f.decks[id] = deck
+	f.updates++
+	return true
+}
+
+// slidesServerHelper fakes the drive and slides apis with the template presentation, which has a
+// shape on a single slide for each text by object ID. Copies of presentations are named deck-1,
+// deck-2 and so on and uploaded images are named after their file
+func slidesServerHelper(t *testing.T, template map[string]string) (slides.GoogleSlides, *slidesFake) {
+	fake := &slidesFake{decks: map[string]map[string]slidesElement{"template-id": {}}, images: map[string]bool{}}
+	for objectID, text := range template {
+		fake.decks["template-id"][objectID] = slidesElement{page: "p1", text: text}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := path.Base(strings.TrimSuffix(r.URL.Path, "/copy"))
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/copy"):
+			fake.copies++
+			copied := "deck-" + strconv.Itoa(fake.copies)
+			fake.decks[copied] = map[string]slidesElement{}
+			for objectID, el := range fake.decks[id] {
+				fake.decks[copied][objectID] = el
+			}
+			json.NewEncoder(w).Encode(driveapi.File{Id: copied})
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/drive/v3/files":
+			_, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			part, err := multipart.NewReader(r.Body, params["boundary"]).NextPart()
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			var f driveapi.File
+			json.NewDecoder(part).Decode(&f)
+			fake.images[f.Name] = true
+			json.NewEncoder(w).Encode(driveapi.File{Id: f.Name})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/permissions"):
+			json.NewEncoder(w).Encode(driveapi.Permission{Id: "anyoneWithLink"})
+		case r.Method == http.MethodDelete:
+			delete(fake.images, id)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/presentations/"):
+			deck, ok := fake.decks[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			page := &slidesapi.Page{ObjectId: "p1"}
+			for objectID, el := range deck {
+				item := &slidesapi.PageElement{ObjectId: objectID}
+				if el.image != "" {
+					item.Image = &slidesapi.Image{ContentUrl: el.image}
+				} else {
+					item.Shape = &slidesapi.Shape{Text: &slidesapi.TextContent{TextElements: []*slidesapi.TextElement{{TextRun: &slidesapi.TextRun{Content: el.text + "\n"}}}}}
+				}
+				page.PageElements = append(page.PageElements, item)
+			}
+			json.NewEncoder(w).Encode(slidesapi.Presentation{PresentationId: id, Slides: []*slidesapi.Page{page}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			var req slidesapi.BatchUpdatePresentationRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if !fake.apply(strings.TrimSuffix(id, ":batchUpdate"), req.Requests) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte("{}"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	opts := []option.ClientOption{option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL + "/")}
+	slideSvc, err := slidesapi.NewService(context.TODO(), opts...)
+	if err != nil {
+		t.Fatalf("Unable to create slides service. Err: %v", err)
+	}
+	driveSvc, err := driveapi.NewService(context.TODO(), opts...)
+	if err != nil {
+		t.Fatalf("Unable to create drive service. Err: %v", err)
+	}
+	return slides.NewGoogleSlides(logger.LoggerForTests{Tester: t}, slideSvc, driveSvc), fake
+}
+
+func TestEventStore_Sync_Slides(t *testing.T) {
+	dir, err := ioutil.TempDir("", "slides")
+	if err != nil {
+		t.Fatalf("Unable to create temp dir. Err: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	for _, name := range []string{"logo.png", "new-logo.png", "photo.png"} {
+		ioutil.WriteFile(filepath.Join(dir, name), []byte("png"), 0644)
+	}
+
+	startDate := time.Now().AddDate(0, 1, 0).Truncate(time.Hour).UTC()
+	store := dirStoreHelper(t, map[string]string{
+		"meetup-20.yaml": `track_event: true
+start_date: "` + startDate.Format(time.RFC3339) + `"
+title: Meetup 20 - Observability
+description: Some description
+duration: 90
+is_online: true
+organizers:
+- name: Organizer
+  email: organizer@example.com
+agenda:
+- type: speaker
+  topic: Tracing with OpenTelemetry
+  speakers:
+  - name: Jane Doe
+    profile: SRE at Example
+sponsors:
+- name: Example Corp
+  logo: ` + filepath.Join(dir, "logo.png") + `
+`,
+	})
+	// Text of the template that is not a placeholder, e.g. Online, should be kept as it is
+	svc, fake := slidesServerHelper(t, map[string]string{
+		"agenda":  "{{agenda}}",
+		"footer":  "Join us Online at meetup.com",
+		"logo":    "{{sponsor1_logo}}",
+		"photo":   "{{speaker1_photo}}",
+		"speaker": "{{speaker1_name}} - {{speaker1_bio}}",
+		"sponsor": "Thanks to {{sponsor1_name}}",
+		"talk":    "{{talk1_topic}}: {{talk1_synopsis}}",
+		"title":   "{{title}}",
+		"when":    "{{date}} {{time}} | {{venue}}",
+	})
+	s := EventStore{
+		store:          store,
+		logger:         logger.LoggerForTests{Tester: t},
+		featureControl: SubMeetupFeatureControl{SlidesSync: true},
+		location:       time.UTC,
+	}
+	WithSlides(svc, "template-id", "")(&s)
+	when := startDate.Format("Monday, 2 January 2006") + " " + startDate.Format("3:04 PM")
+
+	tests := []struct {
+		name       string
+		edit       func(e Event) Event
+		editSlides func(deck map[string]slidesElement)
+		wantAction Action
+		wantText   string
+	}{
+		{
+			name:       "Slides are created from the template",
+			wantAction: ActionCreate,
+			// Placeholders without a value are left in the slides
+			wantText: `agenda: Tracing with OpenTelemetry - Jane Doe
+footer: Join us Online at meetup.com
+image_logo: [logo.png]
+photo: {{speaker1_photo}}
+speaker: Jane Doe - SRE at Example
+sponsor: Thanks to Example Corp
+talk: Tracing with OpenTelemetry: {{talk1_synopsis}}
+title: Meetup 20 - Observability
+when: ` + when + ` | Online`,
+		},
+		{
+			name: "Shapes with placeholders are filled in again when the event changes",
+			edit: func(e Event) Event {
+				e.Title = "Meetup 20 - Tracing"
+				e.Agenda[0].Speakers[0].Name = "Jane Doe-Smith"
+				e.Agenda[0].Speakers[0].ProfileImage = filepath.Join(dir, "photo.png")
+				e.Sponsors[0].Logo = filepath.Join(dir, "new-logo.png")
+				return e
+			},
+			// Content added by organizers is kept
+			editSlides: func(deck map[string]slidesElement) {
+				deck["notes"] = slidesElement{page: "p1", text: "Jane Doe will demo the Online tracing setup"}
+			},
+			wantAction: ActionUpdate,
+			wantText: `agenda: Tracing with OpenTelemetry - Jane Doe-Smith
+footer: Join us Online at meetup.com
+image_logo: [new-logo.png]
+image_photo: [photo.png]
+notes: Jane Doe will demo the Online tracing setup
+speaker: Jane Doe-Smith - SRE at Example
+sponsor: Thanks to Example Corp
+talk: Tracing with OpenTelemetry: {{talk1_synopsis}}
+title: Meetup 20 - Tracing
+when: ` + when + ` | Online`,
+		},
+		{
+			name:       "Slides are in sync",
+			wantAction: ActionNoop,
+			wantText: `agenda: Tracing with OpenTelemetry - Jane Doe-Smith
+footer: Join us Online at meetup.com
+image_logo: [new-logo.png]
+image_photo: [photo.png]
+notes: Jane Doe will demo the Online tracing setup
+speaker: Jane Doe-Smith - SRE at Example
+sponsor: Thanks to Example Corp
+talk: Tracing with OpenTelemetry: {{talk1_synopsis}}
+title: Meetup 20 - Tracing
+when: ` + when + ` | Online`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.edit != nil {
+				e, _ := store.Get("meetup-20")
+				store.Put(tt.edit(e))
+			}
+			if tt.editSlides != nil {
+				tt.editSlides(fake.decks["deck-1"])
+			}
+			got, err := s.Sync(context.TODO(), SyncOptions{Platform: "slides"})
+			if err != nil {
+				t.Fatalf("EventStore.Sync() error = %v", err)
+			}
+			if len(got.Changes) != 1 || got.Changes[0].Action != tt.wantAction || got.Changes[0].Error != "" {
+				t.Fatalf("EventStore.Sync() = %+v, want %v", got.Changes, tt.wantAction)
+			}
+			e, _ := store.Get("meetup-20")
+			if e.SlidesID != "deck-1" || fake.copies != 1 {
+				t.Errorf("EventStore.Sync() SlidesID = %v after %v copies, want deck-1 to be kept", e.SlidesID, fake.copies)
+			}
+			if got := fake.render(e.SlidesID); got != tt.wantText {
+				t.Errorf("EventStore.Sync() slides =\n%v\nwant\n%v", got, tt.wantText)
+			}
+			if len(fake.images) > 0 {
+				t.Errorf("EventStore.Sync() left uploaded images %v in drive", fake.images)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package eventstore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// LoadSpeakers reads the speakers registry; a yaml file with a list of speakers. Speakers are
+// referred to by ID from the agenda of events so that details of repeat speakers are kept in
+// a single place
+func LoadSpeakers(f string) ([]Speaker, error) {
+	raw, err := ioutil.ReadFile(f)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read speakers registry. Err: %v", err)
+	}
+	var speakers []Speaker
+	err = yaml.Unmarshal(raw, &speakers)
+	if err != nil {
+		return nil, fmt.Errorf("Issue with unmarshalling speakers registry. Err: %v", err)
+	}
+	issues := validateSpeakerRegistry(speakers)
+	if len(issues) > 0 {
+		return nil, fmt.Errorf("Invalid speakers registry %v. %v", f, strings.Join(issues, ". "))
+	}
+	return speakers, nil
+}
+
+func validateSpeakerRegistry(speakers []Speaker) []string {
+	issues := []string{}
+	ids := map[string]bool{}
+	for idx, sp := range speakers {
+		field := fmt.Sprintf("speakers[%v]", idx)
+		if sp.ID == "" {
+			issues = append(issues, fmt.Sprintf("%v: Speaker ID is required", field))
+		} else if ids[sp.ID] {
+			issues = append(issues, fmt.Sprintf("%v: Duplicate speaker ID %v", field, sp.ID))
+		}
+		ids[sp.ID] = true
+		if sp.Name == "" {
+			issues = append(issues, fmt.Sprintf("%v: Speaker name is required", field))
+		}
+		if sp.Email != "" && !validEmail(sp.Email) {
+			issues = append(issues, fmt.Sprintf("%v: Invalid email %v", field, sp.Email))
+		}
+		if sp.ProfileImage != "" {
+			if err := validateImage(sp.ProfileImage); err != nil {
+				issues = append(issues, fmt.Sprintf("%v: %v", field, err))
+			}
+		}
+	}
+	return issues
+}
+
+// WithSpeakers sets the speakers registry that agenda items are able to refer to
+func WithSpeakers(speakers []Speaker) Option {
+	return func(s *EventStore) {
+		s.speakers = map[string]Speaker{}
+		for _, sp := range speakers {
+			s.speakers[sp.ID] = sp
+		}
+	}
+}
+
+// withSpeakers returns a copy of the event with the speakers that agenda items refer to by
+// ID filled in from the speakers registry. The copy is only used for rendering and is never
+// saved so that the eventstore keeps referring to the registry
+func (s EventStore) withSpeakers(e Event) (Event, error) {
+	agenda := make([]AgendaItem, len(e.Agenda))
+	for idx, a := range e.Agenda {
+		speakers := append([]Speaker{}, a.Speakers...)
+		for _, id := range a.SpeakerIDs {
+			sp, ok := s.speakers[id]
+			if !ok {
+				return e, fmt.Errorf("Unknown speaker %v", id)
+			}
+			speakers = append(speakers, sp)
+		}
+		a.Speakers = speakers
+		// Cleared so that resolving the copy again does not add the speakers twice
+		a.SpeakerIDs = nil
+		agenda[idx] = a
+	}
+	e.Agenda = agenda
+	return e, nil
+}
+
+// ValidateSpeakers checks that the speakers that the agenda items refer to exist
+func ValidateSpeakers(events []Event, speakers []Speaker) []ValidationIssue {
+	known := map[string]bool{}
+	for _, sp := range speakers {
+		known[sp.ID] = true
+	}
+	issues := []ValidationIssue{}
+	for idx, e := range events {
+		for agendaIdx, a := range e.Agenda {
+			for speakerIdx, id := range a.SpeakerIDs {
+				if known[id] {
+					continue
+				}
+				field := fmt.Sprintf("agenda[%v].speaker_ids[%v]", agendaIdx, speakerIdx)
+				issues = append(issues, ValidationIssue{Index: idx, EventID: e.ID, Title: e.Title, Field: field, Message: fmt.Sprintf("Unknown speaker %v", id)})
+			}
+		}
+	}
+	return issues
+}
+
+func (s EventStore) speakerList() []Speaker {
+	speakers := []Speaker{}
+	for _, sp := range s.speakers {
+		speakers = append(speakers, sp)
+	}
+	return speakers
+}
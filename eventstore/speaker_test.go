@@ -0,0 +1,127 @@
+package eventstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadSpeakers(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantIDs []string
+		wantErr string
+	}{
+		{
+			name: "Valid registry",
+			content: `- id: jane
+  name: Jane Doe
+  email: jane@example.com
+- id: john
+  name: John Doe
+`,
+			wantIDs: []string{"jane", "john"},
+		},
+		{
+			name: "Duplicate ID",
+			content: `- id: jane
+  name: Jane Doe
+- id: jane
+  name: Jane Tan
+`,
+			wantErr: "speakers[1]: Duplicate speaker ID jane",
+		},
+		{
+			name: "Missing ID and invalid email",
+			content: `- name: Jane Doe
+  email: jane
+`,
+			wantErr: "speakers[0]: Speaker ID is required. speakers[0]: Invalid email jane",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "speakers")
+			if err != nil {
+				t.Fatalf("Unable to create temp dir. Err: %v", err)
+			}
+			t.Cleanup(func() { os.RemoveAll(dir) })
+			f := filepath.Join(dir, "speakers.yaml")
+			ioutil.WriteFile(f, []byte(tt.content), 0644)
+
+			got, err := LoadSpeakers(f)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadSpeakers() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadSpeakers() error = %v", err)
+			}
+			gotIDs := []string{}
+			for _, sp := range got {
+				gotIDs = append(gotIDs, sp.ID)
+			}
+			if strings.Join(gotIDs, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("LoadSpeakers() = %v, want %v", gotIDs, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestValidateSpeakers(t *testing.T) {
+	speakers := []Speaker{{ID: "jane", Name: "Jane Doe"}}
+	events := []Event{
+		{Title: "Inline", Agenda: []AgendaItem{{Type: "speaker", Speakers: []Speaker{{Name: "John Doe"}}}}},
+		{Title: "Known", Agenda: []AgendaItem{{Type: "speaker", SpeakerIDs: []string{"jane"}}}},
+		{Title: "Unknown", Agenda: []AgendaItem{{Type: "break"}, {Type: "speaker", SpeakerIDs: []string{"jane", "john"}}}},
+	}
+	got := ValidateSpeakers(events, speakers)
+	if len(got) != 1 {
+		t.Fatalf("ValidateSpeakers() = %v, want 1 issue", got)
+	}
+	if got[0].Index != 2 || got[0].Field != "agenda[1].speaker_ids[1]" {
+		t.Errorf("ValidateSpeakers() = %v, want issue on agenda[1].speaker_ids[1] of event 2", got[0])
+	}
+}
+
+func TestEventStore_calendarEvent_speakers(t *testing.T) {
+	s := &EventStore{
+		calendarEventInvite:  "Join via %v",
+		descriptionTemplates: DescriptionTemplates{Calendar: "{{ range .Agenda }}{{ .Topic }}: {{ speakerNames .Speakers }}\n{{ end }}"},
+	}
+	WithSpeakers([]Speaker{{ID: "jane", Name: "Jane Doe", Email: "jane@example.com"}})(s)
+	e := Event{
+		StartDate:  time.Date(2020, 10, 15, 19, 30, 0, 0, time.UTC),
+		Organizers: []Organizer{{Name: "Organizer", Email: "organizer@example.com"}},
+		Agenda: []AgendaItem{
+			{Type: "speaker", Topic: "Observability", SpeakerIDs: []string{"jane"}, Speakers: []Speaker{{Name: "John Doe", Email: "john@example.com"}}},
+			{Type: "speaker", Topic: "Tracing", SpeakerIDs: []string{"jane"}},
+		},
+	}
+	got, err := s.calendarEvent(e)
+	if err != nil {
+		t.Fatalf("EventStore.calendarEvent() error = %v", err)
+	}
+	wantAttendees := "jane@example.com,john@example.com,organizer@example.com"
+	if strings.Join(got.Attendees, ",") != wantAttendees {
+		t.Errorf("EventStore.calendarEvent() attendees = %v, want %v", got.Attendees, wantAttendees)
+	}
+	wantDesc := "Observability: John Doe, Jane Doe\nTracing: Jane Doe"
+	if got.Description != wantDesc {
+		t.Errorf("EventStore.calendarEvent() description = %q, want %q", got.Description, wantDesc)
+	}
+	if len(e.Agenda[1].Speakers) != 0 {
+		t.Errorf("EventStore.calendarEvent() altered the agenda of the event")
+	}
+
+	e.Agenda[1].SpeakerIDs = []string{"unknown"}
+	if _, err := s.calendarEvent(e); err == nil {
+		t.Errorf("EventStore.calendarEvent() error = nil, want error for unknown speaker")
+	}
+}
@@ -0,0 +1,56 @@
+package eventstore
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrEventNotFound is returned by a Store when no event matches the requested ID
+var ErrEventNotFound = errors.New("Event not found in store")
+
+// ErrUnknownPlatform is returned when syncing a platform that the eventstore does not handle
+var ErrUnknownPlatform = errors.New("Unknown platform")
+
+// Store persists events. Each Put is expected to be committed on its own so that
+// platform IDs are saved as soon as they are known.
+type Store interface {
+	List() ([]Event, error)
+	Get(id string) (Event, error)
+	Put(e Event) error
+	Delete(id string) error
+}
+
+// NewStore returns the store implementation based on storeType. An empty storeType
+// would default to the yaml file store. The sheets store needs a google sheets client and is
+// set up with NewSheetsStore instead
+func NewStore(storeType, path string) (Store, error) {
+	switch storeType {
+	case "", "yaml":
+		return NewYAMLFileStore(path), nil
+	case "bolt":
+		return NewBoltStore(path), nil
+	case "dir":
+		return NewDirStore(path), nil
+	case "sheets":
+		return nil, fmt.Errorf("The sheets eventstore needs google credentials and can't be set up on its own")
+	default:
+		return nil, fmt.Errorf("Unknown eventstore type: %v", storeType)
+	}
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slugify(s string) string {
+	s = strings.ToLower(s)
+	s = nonSlugChars.ReplaceAllString(s, "-")
+	return strings.Trim(s, "-")
+}
+
+// GenerateEventID provides a stable ID for events that do not have an ID defined yet.
+// It is derived from the start date and title so that repeated reads of the same entry
+// would return the same ID.
+func GenerateEventID(e Event) string {
+	return e.StartDate.Format("20060102") + "-" + slugify(e.Title)
+}
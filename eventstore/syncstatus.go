@@ -0,0 +1,79 @@
+package eventstore
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PlatformSyncStatus records the outcome of syncing an event to a platform. It is managed by
+// the sync and is written back into the eventstore; it should not be edited by hand
+type PlatformSyncStatus struct {
+	LastAttempt time.Time `yaml:"last_attempt,omitempty"`
+	LastSuccess time.Time `yaml:"last_success,omitempty"`
+	LastError   string    `yaml:"last_error,omitempty"`
+	// PayloadHash is the hash of the values that were last pushed to the platform
+	PayloadHash string `yaml:"payload_hash,omitempty"`
+}
+
+// OutOfSync is true if the last attempt to sync the event to the platform failed
+func (p PlatformSyncStatus) OutOfSync() bool {
+	return p.LastError != ""
+}
+
+// payloadHash is the hash of all values that the platform is expected to have
+func (c Change) payloadHash() string {
+	fields := []string{}
+	for f := range c.payload {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	h := sha256.New()
+	for _, f := range fields {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+		h.Write([]byte(c.payload[f]))
+		h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// recordSyncStatus updates the sync status of the platform based on the outcome of the change.
+// Skipped changes are not recorded unless the change could not be planned. Changes that found
+// the platform to be in sync are only recorded if the status needs to be corrected so that the
+// eventstore is not rewritten on every run
+func recordSyncStatus(e Event, c Change, now time.Time) Event {
+	current := e.SyncStatus[c.Platform]
+	updated := current
+	switch {
+	case c.Error != "":
+		updated.LastAttempt = now
+		updated.LastError = c.Error
+	case c.Action == ActionSkip || c.Frozen:
+		return e
+	case c.Action == ActionNoop:
+		if current.LastError == "" && current.PayloadHash == c.payloadHash() {
+			return e
+		}
+		updated.LastSuccess = now
+		updated.LastError = ""
+		updated.PayloadHash = c.payloadHash()
+	case c.Applied:
+		updated.LastAttempt = now
+		updated.LastSuccess = now
+		updated.LastError = ""
+		updated.PayloadHash = c.payloadHash()
+	default:
+		return e
+	}
+
+	// The map is copied as events share the map with the copies made of them
+	status := map[string]PlatformSyncStatus{}
+	for k, v := range e.SyncStatus {
+		status[k] = v
+	}
+	status[c.Platform] = updated
+	e.SyncStatus = status
+	return e
+}
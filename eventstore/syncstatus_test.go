@@ -0,0 +1,92 @@
+package eventstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordSyncStatus(t *testing.T) {
+	now := time.Date(2020, 10, 15, 19, 30, 0, 0, time.UTC)
+	earlier := now.Add(-1 * time.Hour)
+	applied := Change{Platform: "meetup", Action: ActionUpdate, Applied: true}
+	applied.diff("title", "Old", "New")
+	noop := Change{Platform: "meetup", Action: ActionNoop}
+	noop.diff("title", "New", "New")
+
+	tests := []struct {
+		name        string
+		status      map[string]PlatformSyncStatus
+		change      Change
+		wantChanged bool
+		want        PlatformSyncStatus
+	}{
+		{
+			name:        "Applied change",
+			change:      applied,
+			wantChanged: true,
+			want:        PlatformSyncStatus{LastAttempt: now, LastSuccess: now, PayloadHash: applied.payloadHash()},
+		},
+		{
+			name:        "Failed change keeps last success",
+			status:      map[string]PlatformSyncStatus{"meetup": {LastSuccess: earlier, PayloadHash: "abc"}},
+			change:      Change{Platform: "meetup", Action: ActionUpdate, Error: "Unable to update"},
+			wantChanged: true,
+			want:        PlatformSyncStatus{LastAttempt: now, LastSuccess: earlier, LastError: "Unable to update", PayloadHash: "abc"},
+		},
+		{
+			name:        "Skipped change is not recorded",
+			change:      Change{Platform: "meetup", Action: ActionSkip, Reason: "Meetup sync is disabled"},
+			wantChanged: false,
+		},
+		{
+			name:        "In sync platform with matching hash is not recorded",
+			status:      map[string]PlatformSyncStatus{"meetup": {LastSuccess: earlier, PayloadHash: noop.payloadHash()}},
+			change:      noop,
+			wantChanged: false,
+			want:        PlatformSyncStatus{LastSuccess: earlier, PayloadHash: noop.payloadHash()},
+		},
+		{
+			name:        "In sync platform clears last error",
+			status:      map[string]PlatformSyncStatus{"meetup": {LastAttempt: earlier, LastError: "Unable to update"}},
+			change:      noop,
+			wantChanged: true,
+			want:        PlatformSyncStatus{LastAttempt: earlier, LastSuccess: now, PayloadHash: noop.payloadHash()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := Event{SyncStatus: tt.status}
+			got := recordSyncStatus(e, tt.change, now)
+			changed := len(got.SyncStatus) != len(e.SyncStatus) || got.SyncStatus["meetup"] != e.SyncStatus["meetup"]
+			if changed != tt.wantChanged {
+				t.Errorf("recordSyncStatus() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if got.SyncStatus["meetup"] != tt.want {
+				t.Errorf("recordSyncStatus() = %+v, want %+v", got.SyncStatus["meetup"], tt.want)
+			}
+		})
+	}
+}
+
+func TestYAMLFileStore_SyncStatus(t *testing.T) {
+	store := yamlStoreHelper(t, sampleEvents)
+	events, err := store.List()
+	if err != nil {
+		t.Fatalf("YAMLFileStore.List() error = %v", err)
+	}
+	lastAttempt := time.Date(2020, 10, 15, 19, 30, 0, 0, time.UTC)
+	e := events[0]
+	e.SyncStatus = map[string]PlatformSyncStatus{"meetup": {LastAttempt: lastAttempt, LastError: "Unable to update"}}
+	err = store.Put(e)
+	if err != nil {
+		t.Fatalf("YAMLFileStore.Put() error = %v", err)
+	}
+	got, err := store.Get(e.ID)
+	if err != nil {
+		t.Fatalf("YAMLFileStore.Get() error = %v", err)
+	}
+	status := got.SyncStatus["meetup"]
+	if !status.LastAttempt.Equal(lastAttempt) || status.LastError != "Unable to update" || !status.OutOfSync() {
+		t.Errorf("YAMLFileStore.Get() sync status = %+v", status)
+	}
+}
@@ -0,0 +1,354 @@
+package eventstore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// ValidationIssue is a single problem found with an event. Line and Column are only
+// available when the events are validated from the yaml file
+type ValidationIssue struct {
+	// Index is the position of the event in the eventstore
+	Index   int
+	EventID string
+	Title   string
+	// Field is the path to the offending value, e.g. agenda[0].speakers[1].email
+	Field   string
+	Message string
+	Line    int
+	Column  int
+}
+
+func (v ValidationIssue) Error() string {
+	location := fmt.Sprintf("event %v", v.Index)
+	if v.Line > 0 {
+		location = fmt.Sprintf("line %v, column %v", v.Line, v.Column)
+	}
+	if v.Field == "" {
+		return fmt.Sprintf("%v: %v", location, v.Message)
+	}
+	return fmt.Sprintf("%v: %v: %v", location, v.Field, v.Message)
+}
+
+// ValidateEvents checks all events and returns every issue found. Checks that span
+// across events such as duplicated titles and IDs are included
+func ValidateEvents(events []Event) []ValidationIssue {
+	issues := []ValidationIssue{}
+	titles := map[string]int{}
+	ids := map[string]int{}
+	for idx, e := range events {
+		for _, issue := range validateEvent(e) {
+			issue.Index = idx
+			issues = append(issues, issue)
+		}
+
+		newIssue := func(field, message string) ValidationIssue {
+			return ValidationIssue{Index: idx, EventID: e.ID, Title: e.Title, Field: field, Message: message}
+		}
+		if e.Title != "" {
+			if other, ok := titles[e.Title]; ok {
+				issues = append(issues, newIssue("title", fmt.Sprintf("Title is already used by event %v", other)))
+			} else {
+				titles[e.Title] = idx
+			}
+		}
+		id := e.ID
+		if id == "" {
+			id = GenerateEventID(e)
+		}
+		if other, ok := ids[id]; ok {
+			issues = append(issues, newIssue("id", fmt.Sprintf("ID %v is already used by event %v", id, other)))
+		} else {
+			ids[id] = idx
+		}
+	}
+	return issues
+}
+
+// validateEvent checks a single event. Index is not set on the returned issues
+func validateEvent(e Event) []ValidationIssue {
+	issues := []ValidationIssue{}
+	add := func(field, message string) {
+		issues = append(issues, ValidationIssue{EventID: e.ID, Title: e.Title, Field: field, Message: message})
+	}
+
+	// Images are no longer uploaded once the event started and may have been cleaned up since,
+	// so only their format is checked
+	checkImage := validateImage
+	if !e.StartDate.IsZero() && !e.StartDate.After(time.Now()) {
+		checkImage = validateImageFormat
+	}
+
+	if e.Title == "" {
+		add("title", "Title is required")
+	}
+	if e.Description == "" {
+		add("description", "Description is required")
+	}
+	if e.StartDate.IsZero() {
+		add("start_date", "Start date is required")
+	}
+	if e.Duration <= 0 {
+		add("duration", "Duration in minutes is required")
+	}
+	if e.Status != StatusScheduled && e.Status != StatusCancelled && e.Status != StatusPostponed {
+		add("status", fmt.Sprintf("Unknown status %v. Status can only be empty, cancelled or postponed", e.Status))
+	}
+	if len(e.Organizers) == 0 {
+		add("organizers", "At least one organizer is required")
+	}
+	for idx, o := range e.Organizers {
+		field := fmt.Sprintf("organizers[%v]", idx)
+		if o.Name == "" {
+			add(field+".name", "Organizer name is required")
+		}
+		if o.Email == "" {
+			add(field+".email", "Organizer email is required")
+		} else if !validEmail(o.Email) {
+			add(field+".email", fmt.Sprintf("Invalid email %v", o.Email))
+		}
+	}
+	for idx, a := range e.Agenda {
+		field := fmt.Sprintf("agenda[%v]", idx)
+		switch a.Type {
+		case "break":
+		case "speaker":
+			if a.Topic == "" {
+				add(field+".topic", "Topic is required for speaker agenda items")
+			}
+			if len(a.Speakers) == 0 && len(a.SpeakerIDs) == 0 {
+				add(field+".speakers", "At least one speaker is required for speaker agenda items")
+			}
+		default:
+			add(field+".type", fmt.Sprintf("Unknown agenda type %q. Type can only be break or speaker", a.Type))
+		}
+		for speakerIdx, sp := range a.Speakers {
+			speakerField := fmt.Sprintf("%v.speakers[%v]", field, speakerIdx)
+			if sp.Name == "" {
+				add(speakerField+".name", "Speaker name is required")
+			}
+			if sp.Email != "" && !validEmail(sp.Email) {
+				add(speakerField+".email", fmt.Sprintf("Invalid email %v", sp.Email))
+			}
+			if sp.ProfileImage != "" {
+				if err := checkImage(sp.ProfileImage); err != nil {
+					add(speakerField+".profile_image", err.Error())
+				}
+			}
+		}
+	}
+	for idx, sp := range e.Sponsors {
+		field := fmt.Sprintf("sponsors[%v]", idx)
+		if sp.Name == "" {
+			add(field+".name", "Sponsor name is required")
+		}
+		if sp.Logo != "" {
+			if err := checkImage(sp.Logo); err != nil {
+				add(field+".logo", err.Error())
+			}
+		}
+	}
+	// Generated banners only exist after the banner step is run
+	if e.FeaturedImagePath != "" && !e.GenerateBannerImage {
+		if err := checkImage(e.FeaturedImagePath); err != nil {
+			add("featured_image_path", err.Error())
+		}
+	}
+	return issues
+}
+
+func validEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
+// validateImage checks that the image is a jpg or png file that can be uploaded
+func validateImage(path string) error {
+	err := validateImageFormat(path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("Image %v is not accessible. Err: %v", path, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("Image %v is a directory", path)
+	}
+	return nil
+}
+
+func validateImageFormat(path string) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg", ".png":
+		return nil
+	default:
+		return fmt.Errorf("Image %v needs to be a jpg or png file", path)
+	}
+}
+
+// ValidateFile validates all events in a yaml eventstore file. Issues are returned with the
+// line and column of the offending value in the file. Events that cannot be decoded are
+// reported as issues as well; an error is only returned if the file cannot be read or is
+// not valid yaml
+func ValidateFile(path string, venues []Venue, speakers []Speaker) ([]ValidationIssue, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yamlv3.Node
+	err = yamlv3.Unmarshal(raw, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse eventstore file. Err: %v", err)
+	}
+	if len(doc.Content) == 0 {
+		return []ValidationIssue{}, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yamlv3.SequenceNode {
+		return []ValidationIssue{{Message: "Eventstore file needs to be a list of events", Line: root.Line, Column: root.Column}}, nil
+	}
+
+	issues := []ValidationIssue{}
+	reported := map[string]bool{}
+	undecoded := map[int]bool{}
+	events := []Event{}
+	for idx, item := range root.Content {
+		nodeIssues, sanitized := validateDateNodes(item)
+		for _, issue := range nodeIssues {
+			issue.Index = idx
+			reported[fmt.Sprintf("%v.%v", idx, issue.Field)] = true
+			issues = append(issues, issue)
+		}
+		var e Event
+		err := sanitized.Decode(&e)
+		if err != nil {
+			undecoded[idx] = true
+			issues = append(issues, ValidationIssue{Index: idx, Message: fmt.Sprintf("Unable to decode event. Err: %v", err), Line: item.Line, Column: item.Column})
+		}
+		events = append(events, e)
+	}
+
+	eventIssues := append(ValidateEvents(events), ValidateVenues(events, venues)...)
+	eventIssues = append(eventIssues, ValidateSpeakers(events, speakers)...)
+	for _, issue := range eventIssues {
+		// Problems with dates were already reported with a more precise message
+		if undecoded[issue.Index] || reported[fmt.Sprintf("%v.%v", issue.Index, issue.Field)] {
+			continue
+		}
+		node := findNode(root.Content[issue.Index], issue.Field)
+		issue.Line = node.Line
+		issue.Column = node.Column
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// validateDateNodes checks start_date and timezone of an event node. As an event with
+// an invalid date cannot be decoded, a copy of the node with the offending values
+// replaced is returned so that the rest of the event can still be validated
+func validateDateNodes(item *yamlv3.Node) ([]ValidationIssue, *yamlv3.Node) {
+	issues := []ValidationIssue{}
+	if item.Kind != yamlv3.MappingNode {
+		return []ValidationIssue{{Message: "Event needs to be a mapping of fields", Line: item.Line, Column: item.Column}}, item
+	}
+	add := func(node *yamlv3.Node, field, message string) {
+		issues = append(issues, ValidationIssue{Field: field, Message: message, Line: node.Line, Column: node.Column})
+	}
+
+	sanitized := *item
+	sanitized.Content = append([]*yamlv3.Node{}, item.Content...)
+	replace := func(field, value string) {
+		for i := 0; i+1 < len(sanitized.Content); i += 2 {
+			if sanitized.Content[i].Value == field {
+				sanitized.Content[i+1] = &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: value}
+			}
+		}
+	}
+
+	var loc *time.Location
+	if tz := mappingValue(item, "timezone"); tz != nil && tz.Value != "" {
+		var err error
+		loc, err = time.LoadLocation(tz.Value)
+		if err != nil {
+			add(tz, "timezone", fmt.Sprintf("Unknown time zone %v", tz.Value))
+			replace("timezone", "")
+			loc = nil
+		}
+	}
+
+	startDate := mappingValue(item, "start_date")
+	if startDate == nil || startDate.Value == "" {
+		node := item
+		if startDate != nil {
+			node = startDate
+		}
+		add(node, "start_date", "Start date is required")
+		replace("start_date", "0001-01-01T00:00:00Z")
+		return issues, &sanitized
+	}
+	_, err := time.Parse(time.RFC3339, startDate.Value)
+	if err != nil && loc != nil {
+		_, err = time.ParseInLocation("2006-01-02T15:04:05", startDate.Value, loc)
+	}
+	if err != nil {
+		message := fmt.Sprintf("Start date %v needs to be in the 2006-01-02T15:04:05+08:00 format", startDate.Value)
+		if loc != nil {
+			message = fmt.Sprintf("Start date %v needs to be in the 2006-01-02T15:04:05 or 2006-01-02T15:04:05+08:00 format", startDate.Value)
+		}
+		add(startDate, "start_date", message)
+		replace("start_date", "0001-01-01T00:00:00Z")
+	}
+	return issues, &sanitized
+}
+
+func mappingValue(node *yamlv3.Node, key string) *yamlv3.Node {
+	if node.Kind != yamlv3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+var fieldPathPart = regexp.MustCompile(`^(\w+)(?:\[(\d+)\])?$`)
+
+// findNode walks down a field path such as agenda[0].speakers[1].email and returns the
+// deepest node that exists along the path
+func findNode(node *yamlv3.Node, field string) *yamlv3.Node {
+	if field == "" {
+		return node
+	}
+	for _, part := range strings.Split(field, ".") {
+		matches := fieldPathPart.FindStringSubmatch(part)
+		if matches == nil {
+			return node
+		}
+		value := mappingValue(node, matches[1])
+		if value == nil {
+			return node
+		}
+		node = value
+		if matches[2] == "" {
+			continue
+		}
+		idx, _ := strconv.Atoi(matches[2])
+		if node.Kind != yamlv3.SequenceNode || idx >= len(node.Content) {
+			return node
+		}
+		node = node.Content[idx]
+	}
+	return node
+}
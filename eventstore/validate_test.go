@@ -0,0 +1,122 @@
+package eventstore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateFile(t *testing.T) {
+	image := imageHelper(t)
+	tests := []struct {
+		name       string
+		content    string
+		wantIssues []string
+	}{
+		{
+			name: "Valid event",
+			content: fmt.Sprintf(`- title: Webinar 78 - Observability
+  description: Some description
+  start_date: "2020-10-15T19:30:00+08:00"
+  duration: 90
+  featured_image_path: %v
+  organizers:
+  - name: Organizer
+    email: organizer@example.com
+  agenda:
+  - type: speaker
+    topic: Tracing
+    speakers:
+    - name: Speaker
+      email: speaker@example.com
+  - type: break
+`, image),
+			wantIssues: []string{},
+		},
+		{
+			name: "Missing images of past events are not checked",
+			content: `- title: Webinar 78 - Observability
+  description: Some description
+  start_date: "2020-10-15T19:30:00+08:00"
+  duration: 90
+  featured_image_path: missing-banner.png
+  organizers:
+  - name: Organizer
+    email: organizer@example.com
+  sponsors:
+  - name: Sponsor
+    logo: missing-logo.png
+`,
+			wantIssues: []string{},
+		},
+		{
+			name: "Missing images of upcoming events are reported",
+			content: `- title: Webinar 78 - Observability
+  description: Some description
+  start_date: "2099-10-15T19:30:00+08:00"
+  duration: 90
+  featured_image_path: missing-banner.png
+  organizers:
+  - name: Organizer
+    email: organizer@example.com
+`,
+			wantIssues: []string{
+				"line 5, column 24: featured_image_path: Image missing-banner.png is not accessible. Err: stat missing-banner.png: no such file or directory",
+			},
+		},
+		{
+			name: "All issues are reported",
+			content: `- title: Webinar 78 - Observability
+  description: Some description
+  start_date: "15 Oct 2020"
+  duration: 90
+  featured_image_path: banner.gif
+  organizers:
+  - name: Organizer
+    email: not-an-email
+  agenda:
+  - type: lunch
+- title: Webinar 78 - Observability
+  start_date: "2020-10-29T19:30:00+08:00"
+  timezone: Mars/Olympus
+`,
+			wantIssues: []string{
+				"line 3, column 15: start_date: Start date 15 Oct 2020 needs to be in the 2006-01-02T15:04:05+08:00 format",
+				"line 13, column 13: timezone: Unknown time zone Mars/Olympus",
+				"line 8, column 12: organizers[0].email: Invalid email not-an-email",
+				"line 10, column 11: agenda[0].type: Unknown agenda type \"lunch\". Type can only be break or speaker",
+				"line 5, column 24: featured_image_path: Image banner.gif needs to be a jpg or png file",
+				"line 11, column 3: description: Description is required",
+				"line 11, column 3: duration: Duration in minutes is required",
+				"line 11, column 3: organizers: At least one organizer is required",
+				"line 11, column 10: title: Title is already used by event 0",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "eventstore")
+			if err != nil {
+				t.Fatalf("Unable to create temp dir. Err: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			f := filepath.Join(dir, "events.yaml")
+			ioutil.WriteFile(f, []byte(tt.content), 0644)
+
+			got, err := ValidateFile(f, nil, nil)
+			if err != nil {
+				t.Fatalf("ValidateFile() error = %v", err)
+			}
+			if len(got) != len(tt.wantIssues) {
+				t.Fatalf("ValidateFile() = %v, want %v", got, tt.wantIssues)
+			}
+			for idx, issue := range got {
+				if issue.Error() != tt.wantIssues[idx] {
+					t.Errorf("ValidateFile() issue = %v, want %v", issue.Error(), tt.wantIssues[idx])
+				}
+			}
+		})
+	}
+}
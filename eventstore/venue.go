@@ -0,0 +1,170 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/eventmgmt"
+)
+
+// Venue is a physical location for in person and hybrid events. Events refer to venues by ID
+type Venue struct {
+	ID      string `yaml:"id"`
+	Name    string `yaml:"name"`
+	Address string `yaml:"address"`
+	City    string `yaml:"city"`
+	// Country is the ISO 3166 two letter country code, e.g. sg
+	Country    string `yaml:"country"`
+	Capacity   int    `yaml:"capacity"`
+	Directions string `yaml:"directions"`
+	MapLink    string `yaml:"map_link"`
+	// MeetupVenueID skips the lookup of the venue on meetup. The venue is looked up by name
+	// and address and is created on meetup if it does not exist
+	MeetupVenueID string `yaml:"meetup_venue_id"`
+}
+
+// Location is the address of the venue in a single line, used for calendar invites
+func (v Venue) Location() string {
+	parts := []string{}
+	for _, p := range []string{v.Name, v.Address, v.City} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// HowToFindUs are the directions to the venue that are shown on meetup
+func (v Venue) HowToFindUs() string {
+	if v.MapLink == "" {
+		return v.Directions
+	}
+	if v.Directions == "" {
+		return v.MapLink
+	}
+	return v.Directions + "\n" + v.MapLink
+}
+
+// WithVenues sets the venues that events are able to refer to
+func WithVenues(venues []Venue) Option {
+	return func(s *EventStore) {
+		s.venues = map[string]Venue{}
+		s.meetupVenueIDs = &venueCache{ids: map[string]string{}}
+		for _, v := range venues {
+			s.venues[v.ID] = v
+		}
+	}
+}
+
+// IsInPerson is true for events that are held at a venue. Hybrid events are both online
+// and in person
+func (e Event) IsInPerson() bool {
+	return e.Venue != ""
+}
+
+// venue returns the venue of the event. Online only events do not have a venue
+func (s *EventStore) venue(e Event) (Venue, error) {
+	if e.Venue == "" {
+		return Venue{}, nil
+	}
+	v, ok := s.venues[e.Venue]
+	if !ok {
+		return Venue{}, fmt.Errorf("Unknown venue %v", e.Venue)
+	}
+	return v, nil
+}
+
+// venueCache holds the meetup venue IDs of venues. It is shared by the workers that sync
+// events concurrently; lookups are done one at a time so that a venue is only created once
+type venueCache struct {
+	mu  sync.Mutex
+	ids map[string]string
+}
+
+// meetupVenueID looks up the venue on meetup by name and address. If the venue is not
+// found, it would be created if create is set. Otherwise, an empty ID is returned
+func (s *EventStore) meetupVenueID(ctx context.Context, v Venue, create bool) (string, error) {
+	if v.MeetupVenueID != "" {
+		return v.MeetupVenueID, nil
+	}
+	if s.meetupVenueIDs == nil {
+		s.meetupVenueIDs = &venueCache{ids: map[string]string{}}
+	}
+	s.meetupVenueIDs.mu.Lock()
+	defer s.meetupVenueIDs.mu.Unlock()
+	if id, ok := s.meetupVenueIDs.ids[v.ID]; ok {
+		return id, nil
+	}
+	venues, err := s.meetupClient.ListVenues(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, mv := range venues {
+		if strings.EqualFold(mv.Name, v.Name) && strings.EqualFold(mv.Address, v.Address) {
+			s.meetupVenueIDs.ids[v.ID] = mv.ID
+			return mv.ID, nil
+		}
+	}
+	if !create {
+		return "", nil
+	}
+	s.logger.Infof("Creating venue %v on meetup", v.Name)
+	created, err := s.meetupClient.CreateVenue(ctx, eventmgmt.Venue{
+		Name:    v.Name,
+		Address: v.Address,
+		City:    v.City,
+		Country: v.Country,
+	})
+	if err != nil {
+		return "", err
+	}
+	s.meetupVenueIDs.ids[v.ID] = created.ID
+	return created.ID, nil
+}
+
+// meetupLocation returns where the event would be held on meetup. Online only events have
+// no venue and use the youtube link as directions. The venue is only created on meetup if
+// create is set, otherwise the venue ID might only be known after apply
+func (s *EventStore) meetupLocation(ctx context.Context, e Event, create bool) (eventmgmt.Event, error) {
+	if !e.IsInPerson() {
+		return eventmgmt.Event{HowToFindUs: e.YoutubeLink}, nil
+	}
+	v, err := s.venue(e)
+	if err != nil {
+		return eventmgmt.Event{}, err
+	}
+	venueID, err := s.meetupVenueID(ctx, v, create)
+	if err != nil {
+		return eventmgmt.Event{}, err
+	}
+	if venueID == "" {
+		venueID = knownAfterApply
+	}
+	return eventmgmt.Event{VenueID: venueID, HowToFindUs: v.HowToFindUs(), RSVPLimit: v.Capacity}, nil
+}
+
+// meetupDescription is the description shown on meetup. The youtube link is added to the
+// description of events that are streamed
+func meetupDescription(desc string, e Event) string {
+	if !e.IsOnline {
+		return desc
+	}
+	return eventmgmt.AppendYoutubeLinktoDesc(desc, e.YoutubeLink)
+}
+
+// ValidateVenues checks that the venues that the events refer to exist
+func ValidateVenues(events []Event, venues []Venue) []ValidationIssue {
+	known := map[string]bool{}
+	for _, v := range venues {
+		known[v.ID] = true
+	}
+	issues := []ValidationIssue{}
+	for idx, e := range events {
+		if e.Venue != "" && !known[e.Venue] {
+			issues = append(issues, ValidationIssue{Index: idx, EventID: e.ID, Title: e.Title, Field: "venue", Message: fmt.Sprintf("Unknown venue %v", e.Venue)})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,65 @@
+package eventstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventStore_calendarEvent_venue(t *testing.T) {
+	venues := []Venue{{ID: "office", Name: "Google Singapore", Address: "70 Pasir Panjang Road", City: "Singapore"}}
+	tests := []struct {
+		name         string
+		event        Event
+		wantLocation string
+		wantDesc     string
+		wantErr      bool
+	}{
+		{
+			name:     "Online event",
+			event:    Event{IsOnline: true, StreamyardID: "abc", Description: "Desc"},
+			wantDesc: "Join via https://streamyard.com/abc",
+		},
+		{
+			name:         "In person event",
+			event:        Event{Venue: "office", Description: "Desc"},
+			wantLocation: "Google Singapore, 70 Pasir Panjang Road, Singapore",
+			wantDesc:     "Desc",
+		},
+		{
+			name:         "Hybrid event",
+			event:        Event{IsOnline: true, Venue: "office", StreamyardID: "abc", Description: "Desc"},
+			wantLocation: "Google Singapore, 70 Pasir Panjang Road, Singapore",
+			wantDesc:     "Join via https://streamyard.com/abc",
+		},
+		{
+			name:    "Unknown venue",
+			event:   Event{Venue: "unknown", Description: "Desc"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &EventStore{calendarEventInvite: "Join via %v"}
+			WithVenues(venues)(s)
+			tt.event.StartDate = time.Date(2020, 10, 15, 19, 30, 0, 0, time.UTC)
+			got, err := s.calendarEvent(tt.event)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EventStore.calendarEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Location != tt.wantLocation {
+				t.Errorf("EventStore.calendarEvent() location = %v, want %v", got.Location, tt.wantLocation)
+			}
+			if got.Description != tt.wantDesc {
+				t.Errorf("EventStore.calendarEvent() description = %v, want %v", got.Description, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestValidateVenues(t *testing.T) {
+	events := []Event{{Title: "Online"}, {Title: "Known", Venue: "office"}, {Title: "Unknown", Venue: "cafe"}}
+	got := ValidateVenues(events, []Venue{{ID: "office"}})
+	if len(got) != 1 || got[0].Index != 2 || got[0].Field != "venue" {
+		t.Errorf("ValidateVenues() = %v, want a single issue for the unknown venue", got)
+	}
+}
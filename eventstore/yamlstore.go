@@ -0,0 +1,137 @@
+package eventstore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// YAMLFileStore keeps all events as a list in a single yaml file. Writes are serialized so
+// that events synced concurrently do not overwrite each other
+type YAMLFileStore struct {
+	filePath string
+	mu       sync.Mutex
+}
+
+func NewYAMLFileStore(f string) *YAMLFileStore {
+	return &YAMLFileStore{
+		filePath: f,
+	}
+}
+
+// WriteYAMLFile writes the events into a new yaml file eventstore
+func WriteYAMLFile(f string, events []Event) error {
+	return NewYAMLFileStore(f).write(events)
+}
+
+func (y *YAMLFileStore) List() ([]Event, error) {
+	return y.read()
+}
+
+func (y *YAMLFileStore) Get(id string) (Event, error) {
+	data, err := y.read()
+	if err != nil {
+		return Event{}, err
+	}
+	for _, e := range data {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return Event{}, ErrEventNotFound
+}
+
+func (y *YAMLFileStore) Put(e Event) error {
+	if e.ID == "" {
+		return fmt.Errorf("Event ID is missing. Title: %v", e.Title)
+	}
+	y.mu.Lock()
+	defer y.mu.Unlock()
+	data, err := y.read()
+	if err != nil {
+		return err
+	}
+	found := false
+	for idx := range data {
+		if data[idx].ID == e.ID {
+			data[idx] = e
+			found = true
+			break
+		}
+	}
+	if !found {
+		data = append(data, e)
+	}
+	return y.write(data)
+}
+
+func (y *YAMLFileStore) Delete(id string) error {
+	y.mu.Lock()
+	defer y.mu.Unlock()
+	data, err := y.read()
+	if err != nil {
+		return err
+	}
+	remaining := []Event{}
+	for _, e := range data {
+		if e.ID == id {
+			continue
+		}
+		remaining = append(remaining, e)
+	}
+	if len(remaining) == len(data) {
+		return ErrEventNotFound
+	}
+	return y.write(remaining)
+}
+
+func (y *YAMLFileStore) read() ([]Event, error) {
+	raw, err := ioutil.ReadFile(y.filePath)
+	if err != nil {
+		return nil, err
+	}
+	var data []Event
+	err = yaml.Unmarshal(raw, &data)
+	if err != nil {
+		return nil, fmt.Errorf("Issue with unmarshalling data. Err: %v", err)
+	}
+	for idx := range data {
+		if data[idx].ID == "" {
+			data[idx].ID = GenerateEventID(data[idx])
+		}
+	}
+	return data, nil
+}
+
+func (y *YAMLFileStore) write(data []Event) error {
+	rawData, err := yaml.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal the yaml file. Err: %v", err)
+	}
+	return writeFileAtomic(y.filePath, rawData)
+}
+
+// writeFileAtomic replaces the file in one go by writing to a temporary file first and renaming
+// it over the original; a failed write would not leave a half written eventstore behind
+func writeFileAtomic(filePath string, rawData []byte) error {
+	tmpFile, err := ioutil.TempFile(filepath.Dir(filePath), filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("Unable to create temporary file. Err: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(rawData); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("Unable to write temporary file. Err: %v", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpFile.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), filePath)
+}
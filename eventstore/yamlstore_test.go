@@ -0,0 +1,173 @@
+package eventstore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func yamlStoreHelper(t *testing.T, content string) *YAMLFileStore {
+	dir, err := ioutil.TempDir("", "eventstore")
+	if err != nil {
+		t.Fatalf("Unable to create temp dir. Err: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	f := filepath.Join(dir, "events.yaml")
+	ioutil.WriteFile(f, []byte(content), 0644)
+	return NewYAMLFileStore(f)
+}
+
+const sampleEvents = `- track_event: true
+  start_date: "2020-10-15T19:30:00+08:00"
+  title: Webinar 78 - Observability
+  description: Some description
+  duration: 90
+- id: custom-id
+  track_event: false
+  start_date: "2020-10-29T19:30:00+08:00"
+  title: Webinar 79 - Serverless
+  description: Some description
+  duration: 90
+`
+
+func TestYAMLFileStore_List(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantIDs []string
+		wantErr bool
+	}{
+		{
+			name:    "Successful case",
+			content: sampleEvents,
+			wantIDs: []string{"20201015-webinar-78-observability", "custom-id"},
+		},
+		{
+			name:    "Bad date format",
+			content: "- title: a\n  start_date: 15 Oct 2020\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			y := yamlStoreHelper(t, tt.content)
+			got, err := y.List()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("YAMLFileStore.List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("YAMLFileStore.List() = %v events, want %v", len(got), len(tt.wantIDs))
+			}
+			for idx, e := range got {
+				if e.ID != tt.wantIDs[idx] {
+					t.Errorf("YAMLFileStore.List() ID = %v, want %v", e.ID, tt.wantIDs[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestYAMLFileStore_Put(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     func(y *YAMLFileStore) Event
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "Update existing event without stored id",
+			event: func(y *YAMLFileStore) Event {
+				e, _ := y.Get("20201015-webinar-78-observability")
+				e.MeetupID = "123"
+				return e
+			},
+			wantCount: 2,
+		},
+		{
+			name: "Add new event",
+			event: func(y *YAMLFileStore) Event {
+				e, _ := y.Get("custom-id")
+				e.ID = "another-id"
+				e.MeetupID = "123"
+				return e
+			},
+			wantCount: 3,
+		},
+		{
+			name: "Missing id",
+			event: func(y *YAMLFileStore) Event {
+				return Event{Title: "No ID"}
+			},
+			wantCount: 2,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			y := yamlStoreHelper(t, sampleEvents)
+			e := tt.event(y)
+			if err := y.Put(e); (err != nil) != tt.wantErr {
+				t.Errorf("YAMLFileStore.Put() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			all, _ := y.List()
+			if len(all) != tt.wantCount {
+				t.Errorf("YAMLFileStore.Put() count = %v, want %v", len(all), tt.wantCount)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := y.Get(e.ID)
+			if err != nil {
+				t.Fatalf("YAMLFileStore.Get() error = %v", err)
+			}
+			if got.MeetupID != e.MeetupID || !got.StartDate.Equal(e.StartDate) {
+				t.Errorf("YAMLFileStore.Get() = %+v, want %+v", got, e)
+			}
+		})
+	}
+}
+
+func TestYAMLFileStore_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{
+			name: "Successful case",
+			id:   "custom-id",
+		},
+		{
+			name:    "Missing event",
+			id:      "unknown",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			y := yamlStoreHelper(t, sampleEvents)
+			if err := y.Delete(tt.id); (err != nil) != tt.wantErr {
+				t.Errorf("YAMLFileStore.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if _, err := y.Get(tt.id); err != ErrEventNotFound {
+				t.Errorf("YAMLFileStore.Get() error = %v, want %v", err, ErrEventNotFound)
+			}
+		})
+	}
+}
+
+// imageHelper creates an empty png file for events that need a featured image
+func imageHelper(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "eventstore")
+	if err != nil {
+		t.Fatalf("Unable to create temp dir. Err: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	f := filepath.Join(dir, "banner.png")
+	ioutil.WriteFile(f, []byte{}, 0644)
+	return f
+}
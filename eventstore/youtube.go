@@ -0,0 +1,149 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/youtube"
+)
+
+// minChapters is the least number of chapters that youtube would show on a video
+const minChapters = 3
+
+// youtubeUpdateWindow is how long after the end of an event its recording is still updated.
+// Recordings that stay private or unavailable would otherwise be retrieved on every sync
+const youtubeUpdateWindow = 7 * 24 * time.Hour
+
+// WithYoutube sets the youtube client used to update the recordings of events after they end
+func WithYoutube(y youtube.Youtube) Option {
+	return func(s *EventStore) {
+		s.youtubeSvc = y
+	}
+}
+
+// Chapter is a section of the recording of an event
+type Chapter struct {
+	Start time.Duration
+	Title string
+}
+
+// String formats the chapter the way that youtube picks it up from video descriptions,
+// e.g. 05:30 Observability
+func (c Chapter) String() string {
+	total := int(c.Start / time.Second)
+	h, m, sec := total/3600, (total%3600)/60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d %v", h, m, sec, c.Title)
+	}
+	return fmt.Sprintf("%02d:%02d %v", m, sec, c.Title)
+}
+
+// Chapters are generated from the durations of the agenda items, starting from the beginning of
+// the recording. Chapters are only known up to the first agenda item without a duration and none
+// are returned if there are too few of them for youtube to show
+func (e Event) Chapters() []Chapter {
+	chapters := []Chapter{}
+	var start time.Duration
+	for idx, a := range e.Agenda {
+		title := a.Topic
+		if title == "" {
+			title = capitalize(a.Type)
+		}
+		chapters = append(chapters, Chapter{Start: start, Title: title})
+		if a.Duration <= 0 && idx != len(e.Agenda)-1 {
+			break
+		}
+		start += time.Duration(a.Duration) * time.Minute
+	}
+	if len(chapters) < minChapters {
+		return nil
+	}
+	return chapters
+}
+
+// capitalize upper-cases the first letter, e.g. break to Break
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+func (s *EventStore) planYoutube(ctx context.Context, e Event) Change {
+	c := newChange(e, "youtube")
+	if !s.featureControl.PostYoutubeSync {
+		return c.skip("Post event youtube sync is disabled")
+	}
+
+	if e.Archived {
+		return c.skip("Event is archived. The recording is no longer updated")
+	}
+
+	if e.Status == StatusCancelled {
+		return c.skip("Event is cancelled. There is no recording to update")
+	}
+
+	if !e.IsOnline || e.YoutubeLink == "" {
+		return c.skip("Event is not streamed to youtube. There is no recording to update")
+	}
+
+	end := e.StartDate.Add(time.Duration(e.Duration) * time.Minute)
+	if time.Now().Before(end) {
+		return c.skip("Event has not ended yet. The recording is updated once the event is over")
+	}
+
+	if time.Now().After(end.Add(youtubeUpdateWindow)) {
+		return c.skip(fmt.Sprintf("Event ended more than %v days ago and the recording is no longer updated. Update the recording on youtube and set archived instead", int(youtubeUpdateWindow.Hours()/24)))
+	}
+
+	videoID, err := youtube.VideoID(e.YoutubeLink)
+	if err != nil {
+		return c.fail(err)
+	}
+	desc, err := s.description(e, "youtube")
+	if err != nil {
+		return c.fail(err)
+	}
+	videos, err := s.youtubeSvc.GetVideos(ctx, videoID)
+	if err != nil {
+		return c.fail(fmt.Errorf("Unable to retrieve recording from youtube. Err: %v VideoID: %v", err, videoID))
+	}
+	if len(videos) == 0 {
+		return c.fail(fmt.Errorf("Unable to find recording on youtube. VideoID: %v", videoID))
+	}
+	c.Action = ActionUpdate
+	c.Reason = "Event is over. The recording is updated and the event is archived"
+	c.diff("title", videos[0].Title, e.Title)
+	c.diff("description", videos[0].Description, desc)
+	c.diff("archived", formatBool(e.Archived), formatBool(true))
+	return c
+}
+
+func (s *EventStore) applyYoutube(ctx context.Context, e Event, c Change) (Event, error) {
+	if c.hasDiff("title") || c.hasDiff("description") {
+		videoID, err := youtube.VideoID(e.YoutubeLink)
+		if err != nil {
+			return e, err
+		}
+		desc, err := s.description(e, "youtube")
+		if err != nil {
+			return e, err
+		}
+		s.logger.Infof("Updating youtube recording of event: %v VideoID: %v", e.Title, videoID)
+		err = s.youtubeSvc.UpdateVideo(ctx, youtube.Video{ID: videoID, Title: e.Title, Description: desc})
+		if err != nil {
+			return e, fmt.Errorf("Unable to update youtube recording. Err: %v", err)
+		}
+	}
+	e.Archived = true
+	return e, nil
+}
+
+func expectedYoutube(e Event) Event {
+	e.Archived = true
+	return e
+}
@@ -0,0 +1,201 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+	"github.com/hairizuanbinnoorazman/techmeetup/youtube"
+	"google.golang.org/api/option"
+	youtubeapi "google.golang.org/api/youtube/v3"
+)
+
+func TestEvent_Chapters(t *testing.T) {
+	tests := []struct {
+		name   string
+		agenda []AgendaItem
+		want   []string
+	}{
+		{
+			name: "All durations known",
+			agenda: []AgendaItem{
+				{Type: "speaker", Topic: "Observability", Duration: 45},
+				{Type: "break", Duration: 15},
+				{Type: "speaker", Topic: "Tracing", Duration: 45},
+			},
+			want: []string{"00:00 Observability", "45:00 Break", "1:00:00 Tracing"},
+		},
+		{
+			name: "Chapters stop at the first unknown duration",
+			agenda: []AgendaItem{
+				{Type: "speaker", Topic: "Observability", Duration: 30},
+				{Type: "speaker", Topic: "Logging", Duration: 30},
+				{Type: "speaker", Topic: "Tracing"},
+				{Type: "speaker", Topic: "Profiling", Duration: 30},
+			},
+			want: []string{"00:00 Observability", "30:00 Logging", "1:00:00 Tracing"},
+		},
+		{
+			name: "Too few chapters",
+			agenda: []AgendaItem{
+				{Type: "speaker", Topic: "Observability", Duration: 45},
+				{Type: "speaker", Topic: "Tracing", Duration: 45},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, c := range (Event{Agenda: tt.agenda}).Chapters() {
+				got = append(got, c.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Event.Chapters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// youtubeServerHelper fakes the youtube api with a single video. Updates to the video are kept
+func youtubeServerHelper(t *testing.T, video *youtubeapi.Video) youtube.Youtube {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			items := []*youtubeapi.Video{}
+			if r.URL.Query().Get("id") == video.Id {
+				items = append(items, video)
+			}
+			json.NewEncoder(w).Encode(youtubeapi.VideoListResponse{Items: items})
+		case http.MethodPut:
+			raw, _ := ioutil.ReadAll(r.Body)
+			var updated youtubeapi.Video
+			json.Unmarshal(raw, &updated)
+			*video = updated
+			w.Write(raw)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	svc, err := youtubeapi.NewService(context.TODO(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("Unable to create youtube service. Err: %v", err)
+	}
+	return youtube.NewYoutube(svc, logger.LoggerForTests{Tester: t}, "")
+}
+
+func TestEventStore_Sync_Youtube(t *testing.T) {
+	ended := time.Now().Add(-3 * time.Hour).Truncate(time.Second)
+	tests := []struct {
+		name         string
+		startDate    time.Time
+		archived     bool
+		wantAction   Action
+		wantArchived bool
+		wantUpdated  bool
+	}{
+		{
+			name:         "Recording of ended event is updated",
+			startDate:    ended,
+			wantAction:   ActionUpdate,
+			wantArchived: true,
+			wantUpdated:  true,
+		},
+		{
+			name:       "Event has not ended",
+			startDate:  time.Now().Add(-30 * time.Minute).Truncate(time.Second),
+			wantAction: ActionSkip,
+		},
+		{
+			name:       "Event ended too long ago",
+			startDate:  time.Now().AddDate(0, 0, -8).Truncate(time.Second),
+			wantAction: ActionSkip,
+		},
+		{
+			name:         "Archived event",
+			startDate:    ended,
+			archived:     true,
+			wantAction:   ActionSkip,
+			wantArchived: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			video := &youtubeapi.Video{Id: "abc123", Snippet: &youtubeapi.VideoSnippet{Title: "Live stream", Description: "Old", CategoryId: "28"}}
+			store := dirStoreHelper(t, map[string]string{
+				"webinar-78.yaml": fmt.Sprintf(`track_event: true
+is_online: true
+archived: %v
+start_date: "%v"
+title: Webinar 78 - Observability
+description: Some description
+duration: 90
+youtube_link: https://www.youtube.com/watch?v=abc123
+organizers:
+- name: Organizer
+  email: organizer@example.com
+agenda:
+- type: speaker
+  topic: Observability
+  duration: 40
+  slides_link: https://example.com/slides
+  speakers:
+  - name: Jane Doe
+    profile: SRE at Example
+- type: break
+  duration: 10
+- type: speaker
+  topic: Tracing
+  duration: 40
+  speakers:
+  - name: John Doe
+`, tt.archived, tt.startDate.Format(time.RFC3339)),
+			})
+			s := EventStore{
+				store:          store,
+				logger:         logger.LoggerForTests{Tester: t},
+				featureControl: SubMeetupFeatureControl{PostYoutubeSync: true},
+				freezeWindow:   &FreezeWindow{Before: 24 * time.Hour},
+				youtubeSvc:     youtubeServerHelper(t, video),
+			}
+			got, err := s.Sync(context.TODO(), SyncOptions{Platform: "youtube"})
+			if err != nil {
+				t.Fatalf("EventStore.Sync() error = %v", err)
+			}
+			if len(got.Changes) != 1 || got.Changes[0].Action != tt.wantAction {
+				t.Fatalf("EventStore.Sync() = %+v, want a single %v", got.Changes, tt.wantAction)
+			}
+			e, _ := store.Get("webinar-78")
+			if e.Archived != tt.wantArchived {
+				t.Errorf("EventStore.Sync() archived = %v, want %v", e.Archived, tt.wantArchived)
+			}
+			wantDesc := `Some description
+
+Observability
+- Jane Doe: SRE at Example
+Slides: https://example.com/slides
+
+Tracing
+- John Doe
+
+Chapters
+00:00 Observability
+40:00 Break
+50:00 Tracing`
+			updated := video.Snippet.Title == "Webinar 78 - Observability"
+			if updated != tt.wantUpdated {
+				t.Fatalf("EventStore.Sync() updated recording = %v, want %v", updated, tt.wantUpdated)
+			}
+			if updated && (video.Snippet.Description != wantDesc || video.Snippet.CategoryId != "28") {
+				t.Errorf("EventStore.Sync() recording = %q (category %v), want %q", video.Snippet.Description, video.Snippet.CategoryId, wantDesc)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Package sheets reads and writes rows of google spreadsheets
+package sheets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+	"google.golang.org/api/sheets/v4"
+)
+
+type GoogleSheets struct {
+	logger       logger.Logger
+	sheetService *sheets.Service
+}
+
+func NewGoogleSheets(logger logger.Logger, sheetService *sheets.Service) GoogleSheets {
+	return GoogleSheets{
+		logger:       logger,
+		sheetService: sheetService,
+	}
+}
+
+// a1 is the A1 notation of the cell range in the sheet. An empty sheet name refers to the
+// first sheet of the spreadsheet
+func a1(sheetName, cells string) string {
+	if sheetName == "" {
+		return cells
+	}
+	return fmt.Sprintf("'%v'!%v", sheetName, cells)
+}
+
+// GetRows retrieves all rows of the sheet as they are displayed. Trailing empty cells of a row
+// are not returned
+func (g *GoogleSheets) GetRows(ctx context.Context, spreadsheetID, sheetName string) ([][]string, error) {
+	getValuesCall := g.sheetService.Spreadsheets.Values.Get(spreadsheetID, a1(sheetName, "A:Z"))
+	getValuesCall = getValuesCall.ValueRenderOption("FORMATTED_VALUE")
+	getValuesCall = getValuesCall.Context(ctx)
+	resp, err := getValuesCall.Do()
+	if err != nil {
+		return [][]string{}, fmt.Errorf("Unable to retrieve rows of spreadsheet. Err: %v", err)
+	}
+	rows := [][]string{}
+	for _, r := range resp.Values {
+		row := []string{}
+		for _, cell := range r {
+			row = append(row, fmt.Sprint(cell))
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// UpdateRow overwrites the row of the sheet starting from the first column. Rows are numbered
+// from 1
+func (g *GoogleSheets) UpdateRow(ctx context.Context, spreadsheetID, sheetName string, row int, values []string) error {
+	cells := a1(sheetName, fmt.Sprintf("A%v", row))
+	updateValuesCall := g.sheetService.Spreadsheets.Values.Update(spreadsheetID, cells, &sheets.ValueRange{Values: [][]interface{}{toCells(values)}})
+	updateValuesCall = updateValuesCall.ValueInputOption("RAW")
+	updateValuesCall = updateValuesCall.Context(ctx)
+	_, err := updateValuesCall.Do()
+	if err != nil {
+		return fmt.Errorf("Unable to update row %v of spreadsheet. Err: %v", row, err)
+	}
+	return nil
+}
+
+// UpdateCells overwrites single cells of the row so that the other cells of the row, e.g.
+// formulas or dates, are left as they are. Cells are keyed by their column, starting from 0.
+// Values are written as they are, e.g. strings are not turned into dates or formulas but
+// booleans are written as booleans. Rows are numbered from 1
+func (g *GoogleSheets) UpdateCells(ctx context.Context, spreadsheetID, sheetName string, row int, cells map[int]interface{}) error {
+	if len(cells) == 0 {
+		return nil
+	}
+	columns := []int{}
+	for col := range cells {
+		columns = append(columns, col)
+	}
+	sort.Ints(columns)
+	data := []*sheets.ValueRange{}
+	for _, col := range columns {
+		data = append(data, &sheets.ValueRange{
+			Range:  a1(sheetName, fmt.Sprintf("%v%v", column(col), row)),
+			Values: [][]interface{}{{cells[col]}},
+		})
+	}
+	batchUpdateCall := g.sheetService.Spreadsheets.Values.BatchUpdate(spreadsheetID, &sheets.BatchUpdateValuesRequest{
+		ValueInputOption: "RAW",
+		Data:             data,
+	})
+	batchUpdateCall = batchUpdateCall.Context(ctx)
+	_, err := batchUpdateCall.Do()
+	if err != nil {
+		return fmt.Errorf("Unable to update cells of row %v of spreadsheet. Err: %v", row, err)
+	}
+	return nil
+}
+
+// column is the letter of the column in A1 notation. Columns are numbered from 0
+func column(idx int) string {
+	name := ""
+	for idx++; idx > 0; idx = (idx - 1) / 26 {
+		name = string(rune('A'+(idx-1)%26)) + name
+	}
+	return name
+}
+
+// AppendRow adds the row after the last row of the sheet
+func (g *GoogleSheets) AppendRow(ctx context.Context, spreadsheetID, sheetName string, values []string) error {
+	appendValuesCall := g.sheetService.Spreadsheets.Values.Append(spreadsheetID, a1(sheetName, "A1"), &sheets.ValueRange{Values: [][]interface{}{toCells(values)}})
+	appendValuesCall = appendValuesCall.ValueInputOption("RAW")
+	appendValuesCall = appendValuesCall.InsertDataOption("INSERT_ROWS")
+	appendValuesCall = appendValuesCall.Context(ctx)
+	_, err := appendValuesCall.Do()
+	if err != nil {
+		return fmt.Errorf("Unable to append row to spreadsheet. Err: %v", err)
+	}
+	return nil
+}
+
+func toCells(values []string) []interface{} {
+	cells := []interface{}{}
+	for _, v := range values {
+		cells = append(cells, v)
+	}
+	return cells
+}
+
+// ClearRow empties all cells of the row. The row itself is kept so that the rows after it do
+// not move. Rows are numbered from 1
+func (g *GoogleSheets) ClearRow(ctx context.Context, spreadsheetID, sheetName string, row int) error {
+	cells := a1(sheetName, fmt.Sprintf("%v:%v", row, row))
+	clearValuesCall := g.sheetService.Spreadsheets.Values.Clear(spreadsheetID, cells, &sheets.ClearValuesRequest{})
+	clearValuesCall = clearValuesCall.Context(ctx)
+	_, err := clearValuesCall.Do()
+	if err != nil {
+		return fmt.Errorf("Unable to clear row %v of spreadsheet. Err: %v", row, err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package sheets
+
+import "testing"
+
+func Test_a1(t *testing.T) {
+	tests := []struct {
+		name      string
+		sheetName string
+		cells     string
+		want      string
+	}{
+		{name: "First sheet", cells: "A:Z", want: "A:Z"},
+		{name: "Named sheet", sheetName: "Events 2021", cells: "A5", want: "'Events 2021'!A5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := a1(tt.sheetName, tt.cells); got != tt.want {
+				t.Errorf("a1() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_column(t *testing.T) {
+	tests := []struct {
+		idx  int
+		want string
+	}{
+		{idx: 0, want: "A"},
+		{idx: 25, want: "Z"},
+		{idx: 26, want: "AA"},
+		{idx: 51, want: "AZ"},
+		{idx: 52, want: "BA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := column(tt.idx); got != tt.want {
+				t.Errorf("column(%v) = %v, want %v", tt.idx, got, tt.want)
+			}
+		})
+	}
+}
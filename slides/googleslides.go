@@ -4,21 +4,28 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/hairizuanbinnoorazman/techmeetup/logger"
+	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/slides/v1"
 )
 
 type GoogleSlides struct {
 	logger       logger.Logger
 	slideService *slides.Service
+	// driveService copies presentations and hosts the images placed on slides. Only needed
+	// to create presentations from templates
+	driveService *drive.Service
 }
 
-func NewGoogleSlides(logger logger.Logger, slideService *slides.Service) GoogleSlides {
+func NewGoogleSlides(logger logger.Logger, slideService *slides.Service, driveService *drive.Service) GoogleSlides {
 	return GoogleSlides{
 		logger:       logger,
 		slideService: slideService,
+		driveService: driveService,
 	}
 }
 
@@ -98,3 +105,216 @@ func (g *GoogleSlides) UpdateText(ctx context.Context, slidesID string, textRepl
 	}
 	return nil
 }
+
+// PresentationLink is the link to edit the presentation
+func PresentationLink(presentationID string) string {
+	return fmt.Sprintf("https://docs.google.com/presentation/d/%v/edit", presentationID)
+}
+
+// CopyPresentation creates a new presentation from the template presentation. The copy is placed
+// in the folder if the folder ID is set, else it is placed next to the template
+func (g *GoogleSlides) CopyPresentation(ctx context.Context, templateID, title, folderID string) (string, error) {
+	if g.driveService == nil {
+		return "", fmt.Errorf("Unable to copy presentation as drive is not set up")
+	}
+	file := &drive.File{Name: title}
+	if folderID != "" {
+		file.Parents = []string{folderID}
+	}
+	copyCall := g.driveService.Files.Copy(templateID, file)
+	copyCall = copyCall.SupportsAllDrives(true)
+	copyCall = copyCall.Context(ctx)
+	resp, err := copyCall.Do()
+	if err != nil {
+		return "", fmt.Errorf("Unable to copy template presentation. Err: %v", err)
+	}
+	return resp.Id, nil
+}
+
+// Image is an image that is uploaded to drive
+type Image struct {
+	FileID string
+	URL    string
+}
+
+// UploadImage uploads a local image to drive so that it can be placed on slides. Slides only
+// fetch images from links that anyone is able to view, so the image is shared with anyone
+// with the link. Slides keep their own copy of placed images, so the uploaded image should be
+// deleted with DeleteFile once it is placed
+func (g *GoogleSlides) UploadImage(ctx context.Context, imagePath string) (Image, error) {
+	if g.driveService == nil {
+		return Image{}, fmt.Errorf("Unable to upload image as drive is not set up")
+	}
+	f, err := os.Open(imagePath)
+	if err != nil {
+		return Image{}, fmt.Errorf("Unable to open image. Err: %v", err)
+	}
+	defer f.Close()
+	createCall := g.driveService.Files.Create(&drive.File{Name: filepath.Base(imagePath)})
+	createCall = createCall.Media(f)
+	createCall = createCall.Context(ctx)
+	resp, err := createCall.Do()
+	if err != nil {
+		return Image{}, fmt.Errorf("Unable to upload image to drive. Err: %v", err)
+	}
+	image := Image{
+		FileID: resp.Id,
+		URL:    fmt.Sprintf("https://drive.google.com/uc?export=download&id=%v", resp.Id),
+	}
+	permissionCall := g.driveService.Permissions.Create(resp.Id, &drive.Permission{Type: "anyone", Role: "reader"})
+	permissionCall = permissionCall.Context(ctx)
+	_, err = permissionCall.Do()
+	if err != nil {
+		return image, fmt.Errorf("Unable to share uploaded image. Err: %v", err)
+	}
+	return image, nil
+}
+
+// DeleteFile deletes the file from drive for good
+func (g *GoogleSlides) DeleteFile(ctx context.Context, fileID string) error {
+	if g.driveService == nil {
+		return fmt.Errorf("Unable to delete file as drive is not set up")
+	}
+	deleteCall := g.driveService.Files.Delete(fileID)
+	deleteCall = deleteCall.SupportsAllDrives(true)
+	deleteCall = deleteCall.Context(ctx)
+	err := deleteCall.Do()
+	if err != nil {
+		return fmt.Errorf("Unable to delete file %v. Err: %v", fileID, err)
+	}
+	return nil
+}
+
+// PageElement is a shape or an image on a slide
+type PageElement struct {
+	ObjectID string
+	PageID   string
+	// Text is the text of shapes without the trailing newline that every shape has. Empty for
+	// images
+	Text    string
+	IsImage bool
+
+	size      *slides.Size
+	transform *slides.AffineTransform
+}
+
+// PageElements lists the shapes and images on the slides of the presentation. Elements within
+// groups and tables are not listed
+func (g *GoogleSlides) PageElements(ctx context.Context, presentationID string) ([]PageElement, error) {
+	getSlidesCall := g.slideService.Presentations.Get(presentationID)
+	getSlidesCall = getSlidesCall.Context(ctx)
+	presentation, err := getSlidesCall.Do()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to retrieve presentation. Err: %v", err)
+	}
+	elements := []PageElement{}
+	for _, page := range presentation.Slides {
+		for _, item := range page.PageElements {
+			if item.Shape == nil && item.Image == nil {
+				continue
+			}
+			e := PageElement{
+				ObjectID:  item.ObjectId,
+				PageID:    page.ObjectId,
+				IsImage:   item.Image != nil,
+				size:      item.Size,
+				transform: item.Transform,
+			}
+			if item.Shape != nil && item.Shape.Text != nil {
+				text := ""
+				for _, t := range item.Shape.Text.TextElements {
+					if t.TextRun != nil {
+						text += t.TextRun.Content
+					}
+				}
+				e.Text = strings.TrimSuffix(text, "\n")
+			}
+			elements = append(elements, e)
+		}
+	}
+	return elements, nil
+}
+
+// Update collects changes to a presentation so that they are applied together with Apply
+type Update struct {
+	requests []*slides.Request
+}
+
+// SetText replaces all text of the shape
+func (u *Update) SetText(e PageElement, text string) {
+	if e.Text != "" {
+		u.requests = append(u.requests, &slides.Request{
+			DeleteText: &slides.DeleteTextRequest{
+				ObjectId:  e.ObjectID,
+				TextRange: &slides.Range{Type: "ALL"},
+			},
+		})
+	}
+	if text != "" {
+		u.requests = append(u.requests, &slides.Request{
+			InsertText: &slides.InsertTextRequest{
+				ObjectId: e.ObjectID,
+				Text:     text,
+			},
+		})
+	}
+}
+
+// ReplaceAllText replaces the text across all slides
+func (u *Update) ReplaceAllText(text, replaceText string) {
+	u.requests = append(u.requests, &slides.Request{
+		ReplaceAllText: &slides.ReplaceAllTextRequest{
+			ReplaceText: replaceText,
+			ContainsText: &slides.SubstringMatchCriteria{
+				MatchCase: true,
+				Text:      text,
+			},
+		},
+	})
+}
+
+// ShapeToImage replaces the shape with the image, which takes the position and size of the shape
+func (u *Update) ShapeToImage(e PageElement, imageObjectID, imageURL string) {
+	u.requests = append(u.requests,
+		&slides.Request{
+			CreateImage: &slides.CreateImageRequest{
+				ObjectId: imageObjectID,
+				Url:      imageURL,
+				ElementProperties: &slides.PageElementProperties{
+					PageObjectId: e.PageID,
+					Size:         e.size,
+					Transform:    e.transform,
+				},
+			},
+		},
+		&slides.Request{
+			DeleteObject: &slides.DeleteObjectRequest{ObjectId: e.ObjectID},
+		},
+	)
+}
+
+// ReplaceImage places another image in the place of the image
+func (u *Update) ReplaceImage(e PageElement, imageURL string) {
+	u.requests = append(u.requests, &slides.Request{
+		ReplaceImage: &slides.ReplaceImageRequest{
+			ImageObjectId:      e.ObjectID,
+			Url:                imageURL,
+			ImageReplaceMethod: "CENTER_INSIDE",
+		},
+	})
+}
+
+// Apply makes the changes to the presentation. Changes are applied in the order they were added
+// and either all or none of them are applied
+func (g *GoogleSlides) Apply(ctx context.Context, presentationID string, u Update) error {
+	if len(u.requests) == 0 {
+		return nil
+	}
+	updateReq := g.slideService.Presentations.BatchUpdate(presentationID, &slides.BatchUpdatePresentationRequest{Requests: u.requests})
+	updateReq = updateReq.Context(ctx)
+	_, err := updateReq.Do()
+	if err != nil {
+		return fmt.Errorf("Update Request failed. Err: %v", err)
+	}
+	return nil
+}
@@ -72,7 +72,7 @@ func TestGoogleSlides_UpdateText(t *testing.T) {
 	type args struct {
 		ctx                context.Context
 		slidesID           string
-		textReplaceRequest []TextOnSlide
+		textReplaceRequest []TextOnSlideReplacer
 	}
 	tests := []struct {
 		name    string
@@ -89,14 +89,16 @@ func TestGoogleSlides_UpdateText(t *testing.T) {
 			args: args{
 				ctx:      context.TODO(),
 				slidesID: "1A8tyh0MoV4BvWvEJLS3DgtdBWiZGF-fWehMehUqFCvQ",
-				textReplaceRequest: []TextOnSlide{
-					TextOnSlide{
+				textReplaceRequest: []TextOnSlideReplacer{
+					TextOnSlideReplacer{
 						SlidePageID: "g93d1feb535_0_0",
 						Text:        "https://www.google.com",
+						ReplaceText: "https://www.google.com",
 					},
-					TextOnSlide{
+					TextOnSlideReplacer{
 						SlidePageID: "g952a3c9ea9_0_0",
 						Text:        "https://www.google.com",
+						ReplaceText: "https://www.google.com",
 					},
 				},
 			},
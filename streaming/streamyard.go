@@ -101,13 +101,14 @@ func (s Streamyard) GetStream(ctx context.Context, streamID string) (Stream, err
 	finalURL, _ := url.ParseRequestURI(initialURL)
 
 	cj := s.createCookiejar(finalURL)
-	s.client.Jar = cj
+	client := *s.client
+	client.Jar = cj
 
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, finalURL.String(), nil)
 	req.Header.Add("content-type", "application/json")
 	req.Header.Add("origin", "https://streamyard.com")
 	req.Header.Add("user-agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_14_3) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/85.0.4183.121 Safari/537.36")
-	resp, err := s.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return Stream{}, err
 	}
@@ -133,8 +134,6 @@ func (s Streamyard) GetStream(ctx context.Context, streamID string) (Stream, err
 	if err != nil {
 		return Stream{}, fmt.Errorf("Unable to parse timeoutputs. Input time value: %v", aa.Outputs[0].PlannedStartTime)
 	}
-	loc, _ := time.LoadLocation("Asia/Singapore")
-	parsedTime = parsedTime.In(loc)
 
 	ds := []Destination{}
 	for _, zz := range aa.Outputs {
@@ -169,7 +168,8 @@ func (s Streamyard) UpdateStream(ctx context.Context, streamID, title string) er
 	finalURL, _ := url.ParseRequestURI(initialURL)
 
 	cj := s.createCookiejar(finalURL)
-	s.client.Jar = cj
+	client := *s.client
+	client.Jar = cj
 
 	type createReq struct {
 		CSRFToken string `json:"csrfToken"`
@@ -185,7 +185,7 @@ func (s Streamyard) UpdateStream(ctx context.Context, streamID, title string) er
 	req.Header.Add("content-type", "application/json")
 	req.Header.Add("origin", "https://streamyard.com")
 	req.Header.Add("user-agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_14_3) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/85.0.4183.121 Safari/537.36")
-	resp, err := s.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("Err while doing request. Err: %v", err)
 	}
@@ -199,6 +199,47 @@ func (s Streamyard) UpdateStream(ctx context.Context, streamID, title string) er
 	return nil
 }
 
+// DeleteStream removes the broadcast from streamyard along with its destinations
+func (s Streamyard) DeleteStream(ctx context.Context, streamID string) error {
+	if streamID == "" {
+		return fmt.Errorf("StreamID is missing. Please provide streamID value first")
+	}
+
+	err := JWTChecker(s.logger, s.jwt)
+	if err != nil {
+		return fmt.Errorf("Error while checking jwt. Err: %v", err)
+	}
+
+	initialURL := fmt.Sprintf("https://streamyard.com/api/broadcasts/%v", streamID)
+	finalURL, _ := url.ParseRequestURI(initialURL)
+
+	cj := s.createCookiejar(finalURL)
+	client := *s.client
+	client.Jar = cj
+
+	type deleteReq struct {
+		CSRFToken string `json:"csrfToken"`
+	}
+	rawReq, _ := json.Marshal(deleteReq{CSRFToken: s.csrfToken})
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodDelete, finalURL.String(), bytes.NewBuffer(rawReq))
+	req.Header.Add("content-type", "application/json")
+	req.Header.Add("origin", "https://streamyard.com")
+	req.Header.Add("user-agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_14_3) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/85.0.4183.121 Safari/537.36")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Err while doing request. Err: %v", err)
+	}
+	rawResp, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("Unable to extract out rawResp. Err: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("Unexpected status error code. StatusCode: %v RawResp: %v", resp.StatusCode, string(rawResp))
+	}
+	return nil
+}
+
 func (s Streamyard) CreateStream(ctx context.Context, title string) (Stream, error) {
 	if title == "" {
 		return Stream{}, fmt.Errorf("No title provided to stream. Please relook at the inputs for this")
@@ -213,7 +254,8 @@ func (s Streamyard) CreateStream(ctx context.Context, title string) (Stream, err
 	finalURL, _ := url.ParseRequestURI(initialURL)
 
 	cj := s.createCookiejar(finalURL)
-	s.client.Jar = cj
+	client := *s.client
+	client.Jar = cj
 
 	type createReq struct {
 		CSRFToken       string `json:"csrfToken"`
@@ -233,7 +275,7 @@ func (s Streamyard) CreateStream(ctx context.Context, title string) (Stream, err
 	req.Header.Add("content-type", "application/json")
 	req.Header.Add("origin", "https://streamyard.com")
 	req.Header.Add("user-agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_14_3) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/85.0.4183.121 Safari/537.36")
-	resp, err := s.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return Stream{}, err
 	}
@@ -267,7 +309,8 @@ func (s Streamyard) CreateDestination(ctx context.Context, destinationStreamType
 	finalURL, _ := url.ParseRequestURI(initialURL)
 
 	cj := s.createCookiejar(finalURL)
-	s.client.Jar = cj
+	client := *s.client
+	client.Jar = cj
 
 	rawImage, err := ioutil.ReadFile(ss.ImagePath)
 	if err != nil {
@@ -329,7 +372,7 @@ func (s Streamyard) CreateDestination(ctx context.Context, destinationStreamType
 	req.Header.Add("content-type", writer.FormDataContentType())
 	req.Header.Add("origin", "https://streamyard.com")
 	req.Header.Add("user-agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_14_3) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/85.0.4183.121 Safari/537.36")
-	resp, err := s.client.Do(req)
+	resp, err := client.Do(req)
 	raw, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return ss, err
@@ -382,7 +425,8 @@ func (s Streamyard) UpdateDestination(ctx context.Context, destinationStreamType
 	finalURL, _ := url.ParseRequestURI(initialURL)
 
 	cj := s.createCookiejar(finalURL)
-	s.client.Jar = cj
+	client := *s.client
+	client.Jar = cj
 
 	body := new(bytes.Buffer)
 	writer := multipart.NewWriter(body)
@@ -424,7 +468,7 @@ func (s Streamyard) UpdateDestination(ctx context.Context, destinationStreamType
 	req.Header.Add("content-type", writer.FormDataContentType())
 	req.Header.Add("origin", "https://streamyard.com")
 	req.Header.Add("user-agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_14_3) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/85.0.4183.121 Safari/537.36")
-	resp, err := s.client.Do(req)
+	resp, err := client.Do(req)
 	raw, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return ss, err
@@ -461,10 +505,11 @@ func (s Streamyard) GetDestinations(ctx context.Context) ([]StreamyardDestinatio
 	finalURL, _ := url.ParseRequestURI(initialURL)
 
 	cj := s.createCookiejar(finalURL)
-	s.client.Jar = cj
+	client := *s.client
+	client.Jar = cj
 
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, finalURL.String(), nil)
-	resp, err := s.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -478,33 +523,73 @@ func (s Streamyard) GetDestinations(ctx context.Context) ([]StreamyardDestinatio
 	return ssResp.Destinations, nil
 }
 
+// ListStreams lists the broadcasts that have yet to be completed
 func (s Streamyard) ListStreams(ctx context.Context) ([]Stream, error) {
+	return s.listStreams(ctx, false)
+}
+
+// ListCompletedStreams lists the broadcasts that have been completed
+func (s Streamyard) ListCompletedStreams(ctx context.Context) ([]Stream, error) {
+	return s.listStreams(ctx, true)
+}
+
+// streamyardPageSize is the number of broadcasts that are requested per page
+const streamyardPageSize = 10
+
+// listStreams goes through all pages of broadcasts. An error is returned if any page can't be
+// retrieved as callers rely on the full list, e.g. to avoid creating duplicate broadcasts
+func (s Streamyard) listStreams(ctx context.Context, isComplete bool) ([]Stream, error) {
 	err := JWTChecker(s.logger, s.jwt)
 	if err != nil {
 		return []Stream{}, fmt.Errorf("Error while checking jwt. Err: %v", err)
 	}
 
-	initialURL := "https://streamyard.com/api/broadcasts?limit=10&isAvailable=true&isComplete=false"
+	ss := []Stream{}
+	for offset := 0; ; offset += streamyardPageSize {
+		ssList, err := s.listStreamsPage(ctx, isComplete, offset)
+		if err != nil {
+			return []Stream{}, err
+		}
+		ss = append(ss, toStreams(ssList.Broadcasts)...)
+		if !ssList.HasMore || len(ssList.Broadcasts) == 0 {
+			return ss, nil
+		}
+	}
+}
+
+func (s Streamyard) listStreamsPage(ctx context.Context, isComplete bool, offset int) (StreamyardListResponse, error) {
+	initialURL := fmt.Sprintf("https://streamyard.com/api/broadcasts?limit=%v&offset=%v&isAvailable=true&isComplete=%v", streamyardPageSize, offset, isComplete)
 	finalURL, _ := url.ParseRequestURI(initialURL)
 
 	cj := s.createCookiejar(finalURL)
-	s.client.Jar = cj
+	client := *s.client
+	client.Jar = cj
 
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, finalURL.String(), nil)
-	resp, err := s.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
-		return []Stream{}, err
+		return StreamyardListResponse{}, fmt.Errorf("Unable to list broadcasts. Err: %v", err)
 	}
+	defer resp.Body.Close()
 
 	raw, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return []Stream{}, err
+		return StreamyardListResponse{}, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return StreamyardListResponse{}, fmt.Errorf("Unable to list broadcasts. Response is not ok.\nStatusCode: %v\nBody: %v", resp.StatusCode, string(raw))
 	}
-	// s.logger.Info(string(raw))
-	ss := []Stream{}
 	var ssList StreamyardListResponse
-	json.Unmarshal(raw, &ssList)
-	for _, item := range ssList.Broadcasts {
+	err = json.Unmarshal(raw, &ssList)
+	if err != nil {
+		return StreamyardListResponse{}, fmt.Errorf("Error in parsing response from streamyard. Err: %v", err)
+	}
+	return ssList, nil
+}
+
+func toStreams(broadcasts []StreamyardBroadcastResponse) []Stream {
+	ss := []Stream{}
+	for _, item := range broadcasts {
 		if len(item.Outputs) == 0 {
 			continue
 		}
@@ -533,7 +618,7 @@ func (s Streamyard) ListStreams(ctx context.Context) ([]Stream, error) {
 			Destinations: ds,
 		})
 	}
-	return ss, nil
+	return ss
 }
 
 func (s *Streamyard) createCookiejar(reqUrl *url.URL) *cookiejar.Jar {
@@ -572,8 +657,10 @@ func JWTChecker(logger logger.Logger, jwtToken string) error {
 	return nil
 }
 
+// streamyardCompatibleTimeFormat formats the time similar to how javascript prints out dates.
+// The offset is taken from the time's own location so events in any time zone are scheduled correctly
 func streamyardCompatibleTimeFormat(t time.Time) string {
-	return t.Format("Mon Jan 02 2006 15:04:05 GMT-0700 (Singapore Standard Time)")
+	return t.Format("Mon Jan 02 2006 15:04:05 GMT-0700") + fmt.Sprintf(" (%v)", t.Location())
 }
 
 func imageTypeDetector(f string) (string, error) {
@@ -1,9 +1,12 @@
-// Package youtube handles logic to be able to pull information from youtube
-// Future versions of this pkg would allow for alternations to youtube description
+// Package youtube handles logic to be able to pull information from youtube as well as
+// updating the title and description of videos
 package youtube
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/hairizuanbinnoorazman/techmeetup/logger"
 	"google.golang.org/api/youtube/v3"
@@ -13,6 +16,8 @@ type Video struct {
 	ID          string
 	Title       string
 	Description string
+	// ViewCount is only available for videos retrieved with GetVideos
+	ViewCount uint64
 }
 
 type Youtube struct {
@@ -21,8 +26,40 @@ type Youtube struct {
 	youtubeSvc *youtube.Service
 }
 
+func NewYoutube(youtubeSvc *youtube.Service, logger logger.Logger, channelID string) Youtube {
+	return Youtube{
+		logger:     logger,
+		channelID:  channelID,
+		youtubeSvc: youtubeSvc,
+	}
+}
+
+// VideoID extracts the ID of the video from the various forms of youtube links, e.g.
+// https://www.youtube.com/watch?v=<id>, https://youtu.be/<id> or https://www.youtube.com/live/<id>
+func VideoID(link string) (string, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("Unable to parse youtube link. Err: %v", err)
+	}
+	host := strings.TrimPrefix(u.Hostname(), "www.")
+	host = strings.TrimPrefix(host, "m.")
+	path := strings.Trim(u.Path, "/")
+	switch {
+	case host == "youtu.be" && path != "":
+		return strings.Split(path, "/")[0], nil
+	case host == "youtube.com" && path == "watch" && u.Query().Get("v") != "":
+		return u.Query().Get("v"), nil
+	case host == "youtube.com":
+		parts := strings.Split(path, "/")
+		if len(parts) == 2 && (parts[0] == "live" || parts[0] == "embed" || parts[0] == "shorts") {
+			return parts[1], nil
+		}
+	}
+	return "", fmt.Errorf("Unable to find video ID in youtube link: %v", link)
+}
+
 func (y Youtube) GetVideos(ctx context.Context, videoIDs ...string) ([]Video, error) {
-	youtubeVideoCall := y.youtubeSvc.Videos.List([]string{"id", "snippet"})
+	youtubeVideoCall := y.youtubeSvc.Videos.List([]string{"id", "snippet", "statistics"})
 	youtubeVideoCall = youtubeVideoCall.Id(videoIDs...)
 	youtubeVideoCall = youtubeVideoCall.Context(ctx)
 	resp, err := youtubeVideoCall.Do()
@@ -31,7 +68,11 @@ func (y Youtube) GetVideos(ctx context.Context, videoIDs ...string) ([]Video, er
 	}
 	lol := []Video{}
 	for _, v := range resp.Items {
-		lol = append(lol, Video{ID: v.Id, Title: v.Snippet.Title, Description: v.Snippet.Description})
+		video := Video{ID: v.Id, Title: v.Snippet.Title, Description: v.Snippet.Description}
+		if v.Statistics != nil {
+			video.ViewCount = v.Statistics.ViewCount
+		}
+		lol = append(lol, video)
 	}
 	return lol, nil
 }
@@ -53,3 +94,28 @@ func (y Youtube) ListVideos(ctx context.Context) ([]Video, error) {
 	}
 	return lol, nil
 }
+
+// UpdateVideo updates the title and description of the video. The rest of the video snippet,
+// e.g. tags and category, is kept as is
+func (y Youtube) UpdateVideo(ctx context.Context, v Video) error {
+	youtubeVideoCall := y.youtubeSvc.Videos.List([]string{"id", "snippet"})
+	youtubeVideoCall = youtubeVideoCall.Id(v.ID)
+	youtubeVideoCall = youtubeVideoCall.Context(ctx)
+	resp, err := youtubeVideoCall.Do()
+	if err != nil {
+		return err
+	}
+	if len(resp.Items) == 0 {
+		return fmt.Errorf("Unable to find video %v", v.ID)
+	}
+	video := resp.Items[0]
+	video.Snippet.Title = v.Title
+	video.Snippet.Description = v.Description
+	youtubeUpdateCall := y.youtubeSvc.Videos.Update([]string{"snippet"}, &youtube.Video{Id: video.Id, Snippet: video.Snippet})
+	youtubeUpdateCall = youtubeUpdateCall.Context(ctx)
+	_, err = youtubeUpdateCall.Do()
+	if err != nil {
+		return fmt.Errorf("Unable to update video %v. Err: %v", v.ID, err)
+	}
+	return nil
+}
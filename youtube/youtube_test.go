@@ -135,3 +135,30 @@ func TestYoutube_GetVideos(t *testing.T) {
 		})
 	}
 }
+
+func TestVideoID(t *testing.T) {
+	tests := []struct {
+		name    string
+		link    string
+		want    string
+		wantErr bool
+	}{
+		{name: "Watch link", link: "https://www.youtube.com/watch?v=abc123&t=10", want: "abc123"},
+		{name: "Short link", link: "https://youtu.be/abc123", want: "abc123"},
+		{name: "Live link", link: "https://youtube.com/live/abc123?feature=share", want: "abc123"},
+		{name: "Channel link", link: "https://www.youtube.com/channel/xyz", wantErr: true},
+		{name: "Not a youtube link", link: "https://streamyard.com/abc123", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VideoID(tt.link)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VideoID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("VideoID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}